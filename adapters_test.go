@@ -0,0 +1,702 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/go-datastructures/trie/ctrie"
+	"github.com/alphadose/haxmap"
+	"github.com/armon/go-radix"
+	"github.com/arriqaaq/art"
+	"github.com/dghubble/trie"
+	"github.com/dolthub/swiss"
+	"github.com/falmar/goradix"
+	"github.com/gammazero/radixtree"
+	"github.com/ironpark/skiplist"
+	cuckoo "github.com/panmari/cuckoofilter"
+	"github.com/snorwin/gorax"
+
+	"code.local/go-benchmarks/charbyteshashmatrix"
+	"code.local/go-benchmarks/charhashmatrix"
+	"code.local/go-benchmarks/charmatrix3d"
+)
+
+// set is the common surface every benchmarked structure is wrapped into,
+// so workloads can be written once and applied to all implementations.
+type set interface {
+	Insert(key string)
+	Contains(key string) bool
+	Remove(key string)
+}
+
+// rangeScanner is implemented by sets that can visit keys in lexical order,
+// starting from the first key that is greater or equal to start.
+// Visiting stops after count keys or when fn returns false.
+type rangeScanner interface {
+	Scan(start string, count int, fn func(key string) bool)
+}
+
+// prefixWalker is implemented by sets that can visit all keys starting with prefix,
+// in lexical order only if the set is an orderedSet too. Visiting stops when fn returns false.
+type prefixWalker interface {
+	WalkPrefix(prefix string, fn func(key string) bool)
+}
+
+// longestPrefixMatcher is implemented by sets that can find the longest stored key which is a prefix of key.
+type longestPrefixMatcher interface {
+	LongestPrefix(key string) (string, bool)
+}
+
+// prefixDeleter is implemented by sets that can natively remove all keys starting with prefix.
+type prefixDeleter interface {
+	DeletePrefix(prefix string)
+}
+
+// orderedSet is implemented by sets that keep keys in lexical order.
+type orderedSet interface {
+	set
+	rangeScanner
+	prefixWalker
+	longestPrefixMatcher
+	Min() (string, bool)
+	Max() (string, bool)
+}
+
+// resetter is implemented by sets that can remove all keys while keeping allocated memory for reuse.
+type resetter interface {
+	Reset()
+}
+
+// sizing tells how a set factory uses its capacity argument.
+type sizing uint8
+
+const (
+	sizingIgnored sizing = iota // capacity is ignored, set grows on demand
+	sizingHint                  // set is pre-sized for capacity keys and grows on demand
+	sizingFixed                 // set is allocated for capacity keys and does not grow
+)
+
+type setFactory struct {
+	name   string
+	new    func(capacity int) set
+	sizing sizing
+}
+
+// setFactories lists all implementations in the same order as BenchmarkSets.
+var setFactories = []setFactory{
+	{"Workiva/go-datastructures/trie/ctrie", newCtrieSet, sizingIgnored},
+	{"local/char-xxhash-matrix", newCharHashMatrixSet, sizingIgnored},
+	{"local/char-bytes-hash-matrix", newCharBytesHashMatrixSet, sizingIgnored},
+	{"local/char-matrix-3d", newCharMatrix3DSet, sizingIgnored},
+	{"ironpark/skiplist", newSkiplistSet, sizingIgnored},
+	{"alphadose/haxmap", newHaxmapSet, sizingHint},
+	{"dolthub/swiss", newSwissSet, sizingHint},
+	{"panmari/cuckoofilter", newCuckooFilterSet, sizingFixed},
+	{"dghubble/trie", newPathTrieSet, sizingIgnored},
+	{"falmar/goradix", newGoradixSet, sizingIgnored},
+	{"arriqaaq/art", newARTSet, sizingIgnored},
+	{"gammazero/radixtree", newRadixtreeSet, sizingIgnored},
+	{"snorwin/gorax", newGoraxSet, sizingIgnored},
+	{"armon/go-radix", newArmonRadixSet, sizingIgnored},
+	{"runtime/map", newRuntimeMapSet, sizingHint},
+}
+
+// probeLongestPrefix is used by sets without native longest-prefix match, it looks up every prefix of key.
+func probeLongestPrefix(s set, key string) (string, bool) {
+	for i := len(key); i > 0; i-- {
+		if s.Contains(key[:i]) {
+			return key[:i], true
+		}
+	}
+
+	return "", false
+}
+
+type ctrieSet struct{ ct *ctrie.Ctrie }
+
+func newCtrieSet(int) set { return &ctrieSet{ct: ctrie.New(nil)} }
+
+func (s *ctrieSet) Insert(key string) { s.ct.Insert([]byte(key), struct{}{}) }
+
+func (s *ctrieSet) Contains(key string) bool {
+	_, ok := s.ct.Lookup([]byte(key))
+
+	return ok
+}
+
+func (s *ctrieSet) Remove(key string) { _, _ = s.ct.Remove([]byte(key)) }
+func (s *ctrieSet) Reset()            { s.ct.Clear() }
+
+// WalkPrefix iterates over the whole hash trie, keys are not organised by prefix.
+func (s *ctrieSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	cancel := make(chan struct{})
+	defer close(cancel)
+
+	for entry := range s.ct.Iterator(cancel) {
+		if key := string(entry.Key); strings.HasPrefix(key, prefix) && !fn(key) {
+			return
+		}
+	}
+}
+
+func (s *ctrieSet) LongestPrefix(key string) (string, bool) { return probeLongestPrefix(s, key) }
+
+type charHashMatrixSet struct{ m *charhashmatrix.HashMatrix }
+
+func newCharHashMatrixSet(int) set { return &charHashMatrixSet{m: charhashmatrix.NewMatrix()} }
+
+func (s *charHashMatrixSet) Insert(key string)        { _ = s.m.Set(key) }
+func (s *charHashMatrixSet) Contains(key string) bool { return s.m.Contains(key) }
+func (s *charHashMatrixSet) Remove(key string)        { _ = s.m.Unset(key) }
+
+type charBytesHashMatrixSet struct{ m *charbyteshashmatrix.HashMatrix }
+
+func newCharBytesHashMatrixSet(int) set {
+	return &charBytesHashMatrixSet{m: charbyteshashmatrix.NewMatrix()}
+}
+
+func (s *charBytesHashMatrixSet) Insert(key string)        { _ = s.m.Set(key) }
+func (s *charBytesHashMatrixSet) Contains(key string) bool { return s.m.Contains(key) }
+func (s *charBytesHashMatrixSet) Remove(key string)        { _ = s.m.Unset(key) }
+
+type charMatrix3DSet struct{ m *charmatrix3d.CharMatrix }
+
+// newCharMatrix3DSet sizes the matrix by maximal key length, not by capacity.
+func newCharMatrix3DSet(int) set {
+	return &charMatrix3DSet{m: charmatrix3d.NewMatrix(math.MaxUint8)}
+}
+
+func (s *charMatrix3DSet) Insert(key string)        { _ = s.m.Set([]rune(key)) }
+func (s *charMatrix3DSet) Contains(key string) bool { return s.m.Contains([]rune(key)) }
+func (s *charMatrix3DSet) Remove(key string)        { _ = s.m.Unset([]rune(key)) }
+
+type skiplistSet struct {
+	list skiplist.SkipList[string, struct{}]
+}
+
+func newSkiplistSet(int) set {
+	var comp skiplist.Comparable[string] = func(lhs, rhs string) int {
+		return strings.Compare(lhs, rhs)
+	}
+
+	return &skiplistSet{list: skiplist.New[string, struct{}](comp)}
+}
+
+func (s *skiplistSet) Insert(key string) { s.list.Set(key, struct{}{}) }
+
+func (s *skiplistSet) Contains(key string) bool {
+	_, ok := s.list.GetValue(key)
+
+	return ok
+}
+
+func (s *skiplistSet) Remove(key string) { s.list.Remove(key) }
+
+func (s *skiplistSet) Scan(start string, count int, fn func(key string) bool) {
+	for elem := s.list.Find(start); elem != nil && count > 0; elem = elem.Next() {
+		if !fn(elem.Key()) {
+			return
+		}
+
+		count--
+	}
+}
+
+func (s *skiplistSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	for elem := s.list.Find(prefix); elem != nil && strings.HasPrefix(elem.Key(), prefix); elem = elem.Next() {
+		if !fn(elem.Key()) {
+			return
+		}
+	}
+}
+
+func (s *skiplistSet) LongestPrefix(key string) (string, bool) { return probeLongestPrefix(s, key) }
+
+func (s *skiplistSet) Min() (string, bool) {
+	if elem := s.list.Front(); elem != nil {
+		return elem.Key(), true
+	}
+
+	return "", false
+}
+
+func (s *skiplistSet) Max() (string, bool) {
+	if elem := s.list.Back(); elem != nil {
+		return elem.Key(), true
+	}
+
+	return "", false
+}
+
+type haxmapSet struct {
+	m *haxmap.Map[string, struct{}]
+}
+
+func newHaxmapSet(capacity int) set {
+	return &haxmapSet{m: haxmap.New[string, struct{}](uintptr(capacity))}
+}
+
+func (s *haxmapSet) Insert(key string) { s.m.Set(key, struct{}{}) }
+
+func (s *haxmapSet) Contains(key string) bool {
+	_, ok := s.m.Get(key)
+
+	return ok
+}
+
+func (s *haxmapSet) Remove(key string) { s.m.Del(key) }
+
+type swissSet struct {
+	m *swiss.Map[string, struct{}]
+}
+
+func newSwissSet(capacity int) set {
+	return &swissSet{m: swiss.NewMap[string, struct{}](uint32(capacity))}
+}
+
+func (s *swissSet) Insert(key string)        { s.m.Put(key, struct{}{}) }
+func (s *swissSet) Contains(key string) bool { return s.m.Has(key) }
+func (s *swissSet) Remove(key string)        { s.m.Delete(key) }
+func (s *swissSet) Reset()                   { s.m.Clear() }
+
+type cuckooFilterSet struct{ cf *cuckoo.Filter }
+
+func newCuckooFilterSet(capacity int) set {
+	return &cuckooFilterSet{cf: cuckoo.NewFilter(uint(capacity))}
+}
+
+// Insert skips keys that are already present, cuckoo filter stores duplicates
+// otherwise and repeated updates of the same key would fill it up.
+func (s *cuckooFilterSet) Insert(key string) {
+	bytes := []byte(key)
+
+	if !s.cf.Lookup(bytes) {
+		s.cf.Insert(bytes)
+	}
+}
+
+func (s *cuckooFilterSet) Contains(key string) bool { return s.cf.Lookup([]byte(key)) }
+func (s *cuckooFilterSet) Remove(key string)        { s.cf.Delete([]byte(key)) }
+func (s *cuckooFilterSet) Reset()                   { s.cf.Reset() }
+
+type pathTrieSet struct{ pt *trie.PathTrie }
+
+func newPathTrieSet(int) set { return &pathTrieSet{pt: trie.NewPathTrie()} }
+
+func (s *pathTrieSet) Insert(key string)        { s.pt.Put(key, struct{}{}) }
+func (s *pathTrieSet) Contains(key string) bool { return s.pt.Get(key) != nil }
+func (s *pathTrieSet) Remove(key string)        { s.pt.Delete(key) }
+
+var errStopWalk = errors.New("stop walk")
+
+// WalkPrefix walks the whole trie, path trie has no API to walk a subtree.
+func (s *pathTrieSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	_ = s.pt.Walk(func(key string, _ interface{}) error {
+		if strings.HasPrefix(key, prefix) && !fn(key) {
+			return errStopWalk
+		}
+
+		return nil
+	})
+}
+
+// LongestPrefix probes every prefix, WalkPath of path trie matches only on '/' segment boundaries.
+func (s *pathTrieSet) LongestPrefix(key string) (string, bool) { return probeLongestPrefix(s, key) }
+
+type goradixSet struct{ r *goradix.Radix }
+
+func newGoradixSet(int) set { return &goradixSet{r: goradix.New(false)} }
+
+func (s *goradixSet) Insert(key string) { s.r.Insert(key, struct{}{}) }
+
+func (s *goradixSet) Contains(key string) bool {
+	_, err := s.r.LookUp(key)
+
+	return err == nil
+}
+
+func (s *goradixSet) Remove(key string) { s.r.Remove(key) }
+
+// WalkPrefix looks the prefix up first, AutoComplete does not return the key that equals the prefix.
+func (s *goradixSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	if s.Contains(prefix) && !fn(prefix) {
+		return
+	}
+
+	words, err := s.r.AutoComplete(prefix, true)
+	if err != nil {
+		return
+	}
+
+	for _, key := range words {
+		if !fn(key) {
+			return
+		}
+	}
+}
+
+func (s *goradixSet) LongestPrefix(key string) (string, bool) { return probeLongestPrefix(s, key) }
+
+type artSet struct{ tree *art.Tree }
+
+func newARTSet(int) set { return &artSet{tree: art.NewTree()} }
+
+func (s *artSet) Insert(key string)        { s.tree.Insert([]byte(key), struct{}{}) }
+func (s *artSet) Contains(key string) bool { return s.tree.Search([]byte(key)) != nil }
+
+// Remove looks the key up first, Delete of a missing key dereferences a nil child and panics.
+func (s *artSet) Remove(key string) {
+	bytes := []byte(key)
+
+	if s.tree.Search(bytes) != nil {
+		s.tree.Delete(bytes)
+	}
+}
+
+// Scan has neither seek nor early exit, so it visits every leaf of the tree.
+func (s *artSet) Scan(start string, count int, fn func(key string) bool) {
+	s.tree.Each(func(node *art.Node) {
+		if count <= 0 || !node.IsLeaf() {
+			return
+		}
+
+		key := string(node.Key())
+		if key < start {
+			return
+		}
+
+		count--
+
+		if !fn(key) {
+			count = 0
+		}
+	})
+}
+
+// WalkPrefix has no early exit, remaining leaves under the prefix are still visited.
+func (s *artSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	done := false
+
+	s.tree.Scan([]byte(prefix), func(node *art.Node) {
+		if done || !node.IsLeaf() {
+			return
+		}
+
+		if key := string(node.Key()); strings.HasPrefix(key, prefix) {
+			done = !fn(key)
+		}
+	})
+}
+
+func (s *artSet) LongestPrefix(key string) (string, bool) { return probeLongestPrefix(s, key) }
+
+func (s *artSet) Min() (string, bool) {
+	for it := s.tree.Iterator(); it.HasNext(); {
+		if node := it.Next(); node.IsLeaf() {
+			return string(node.Key()), true
+		}
+	}
+
+	return "", false
+}
+
+// Max iterates over the whole tree, maximum lookup is not exported.
+func (s *artSet) Max() (string, bool) {
+	var (
+		key   string
+		found bool
+	)
+
+	for it := s.tree.Iterator(); it.HasNext(); {
+		if node := it.Next(); node.IsLeaf() {
+			key, found = string(node.Key()), true
+		}
+	}
+
+	return key, found
+}
+
+type radixtreeSet struct{ rt *radixtree.Tree }
+
+func newRadixtreeSet(int) set { return &radixtreeSet{rt: radixtree.New()} }
+
+func (s *radixtreeSet) Insert(key string) { s.rt.Put(key, struct{}{}) }
+
+func (s *radixtreeSet) Contains(key string) bool {
+	_, ok := s.rt.Get(key)
+
+	return ok
+}
+
+func (s *radixtreeSet) Remove(key string) { s.rt.Delete(key) }
+
+func (s *radixtreeSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	s.rt.Walk(prefix, func(key string, _ any) bool {
+		return !fn(key)
+	})
+}
+
+func (s *radixtreeSet) LongestPrefix(key string) (string, bool) {
+	var (
+		longest string
+		found   bool
+	)
+
+	s.rt.WalkPath(key, func(key string, _ any) bool {
+		longest, found = key, true
+
+		return false
+	})
+
+	return longest, found
+}
+
+func (s *radixtreeSet) DeletePrefix(prefix string) { s.rt.DeletePrefix(prefix) }
+
+func (s *radixtreeSet) Min() (string, bool) {
+	var (
+		first string
+		found bool
+	)
+
+	s.rt.Walk("", func(key string, _ any) bool {
+		first, found = key, true
+
+		return true
+	})
+
+	return first, found
+}
+
+// Max iterates over the whole tree, there is no reverse walk.
+func (s *radixtreeSet) Max() (string, bool) {
+	var (
+		last  string
+		found bool
+	)
+
+	s.rt.Walk("", func(key string, _ any) bool {
+		last, found = key, true
+
+		return false
+	})
+
+	return last, found
+}
+
+// Scan has no native seek, so it walks from the root skipping keys before start.
+func (s *radixtreeSet) Scan(start string, count int, fn func(key string) bool) {
+	s.rt.Walk("", func(key string, _ any) bool {
+		if key < start {
+			return false
+		}
+
+		count--
+
+		return !fn(key) || count <= 0
+	})
+}
+
+type goraxSet struct{ t *gorax.Tree }
+
+func newGoraxSet(int) set { return &goraxSet{t: gorax.New()} }
+
+func (s *goraxSet) Insert(key string) { s.t.Insert(key, struct{}{}) }
+
+func (s *goraxSet) Contains(key string) bool {
+	_, ok := s.t.Get(key)
+
+	return ok
+}
+
+func (s *goraxSet) Remove(key string) { s.t.Delete(key) }
+
+// WalkPrefix collects and sorts keys, as gorax walks children of a node in reverse order.
+func (s *goraxSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	var keys []string
+
+	s.t.WalkPrefix(prefix, func(key string, _ interface{}) bool {
+		keys = append(keys, key)
+
+		return false
+	})
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if !fn(key) {
+			return
+		}
+	}
+}
+
+func (s *goraxSet) LongestPrefix(key string) (string, bool) {
+	longest, _, ok := s.t.LongestPrefix(key)
+
+	return longest, ok
+}
+
+func (s *goraxSet) DeletePrefix(prefix string) { s.t.DeletePrefix(prefix) }
+
+func (s *goraxSet) Min() (string, bool) {
+	key, _, ok := s.t.Minimum()
+
+	return key, ok
+}
+
+func (s *goraxSet) Max() (string, bool) {
+	key, _, ok := s.t.Maximum()
+
+	return key, ok
+}
+
+// Scan has no native seek and gorax walks children of a node in reverse order,
+// so it walks the whole tree collecting keys from start and sorts them.
+func (s *goraxSet) Scan(start string, count int, fn func(key string) bool) {
+	var keys []string
+
+	s.t.Walk(func(key string, _ interface{}) bool {
+		if key >= start {
+			keys = append(keys, key)
+		}
+
+		return false
+	})
+
+	slices.Sort(keys)
+
+	for _, key := range keys[:min(count, len(keys))] {
+		if !fn(key) {
+			return
+		}
+	}
+}
+
+type armonRadixSet struct{ r *radix.Tree }
+
+func newArmonRadixSet(int) set { return &armonRadixSet{r: radix.New()} }
+
+func (s *armonRadixSet) Insert(key string) { s.r.Insert(key, struct{}{}) }
+
+func (s *armonRadixSet) Contains(key string) bool {
+	_, ok := s.r.Get(key)
+
+	return ok
+}
+
+func (s *armonRadixSet) Remove(key string) { s.r.Delete(key) }
+
+func (s *armonRadixSet) WalkPrefix(prefix string, fn func(key string) bool) {
+	s.r.WalkPrefix(prefix, func(key string, _ interface{}) bool {
+		return !fn(key)
+	})
+}
+
+func (s *armonRadixSet) LongestPrefix(key string) (string, bool) {
+	longest, _, ok := s.r.LongestPrefix(key)
+
+	return longest, ok
+}
+
+func (s *armonRadixSet) DeletePrefix(prefix string) { s.r.DeletePrefix(prefix) }
+
+func (s *armonRadixSet) Min() (string, bool) {
+	key, _, ok := s.r.Minimum()
+
+	return key, ok
+}
+
+func (s *armonRadixSet) Max() (string, bool) {
+	key, _, ok := s.r.Maximum()
+
+	return key, ok
+}
+
+// Scan has no native seek, so it walks from the root skipping keys before start.
+func (s *armonRadixSet) Scan(start string, count int, fn func(key string) bool) {
+	s.r.Walk(func(key string, _ interface{}) bool {
+		if key < start {
+			return false
+		}
+
+		count--
+
+		return !fn(key) || count <= 0
+	})
+}
+
+type runtimeMapSet map[string]struct{}
+
+func newRuntimeMapSet(capacity int) set {
+	m := make(runtimeMapSet, capacity)
+
+	return &m
+}
+
+func (s *runtimeMapSet) Insert(key string) { (*s)[key] = struct{}{} }
+
+func (s *runtimeMapSet) Contains(key string) bool {
+	_, ok := (*s)[key]
+
+	return ok
+}
+
+func (s *runtimeMapSet) Remove(key string) { delete(*s, key) }
+func (s *runtimeMapSet) Reset()            { clear(*s) }
+
+func TestSetAdapters(t *testing.T) {
+	keys := []string{"b", "a", "c/d", "c", "c/a", "e"}
+
+	for _, f := range setFactories {
+		t.Run(f.name, func(t *testing.T) {
+			s := f.new(len(keys))
+
+			for _, key := range keys {
+				s.Insert(key)
+
+				if !s.Contains(key) {
+					t.Fatalf("Does not contain %q after inserting", key)
+				}
+			}
+
+			if scanner, ok := s.(rangeScanner); ok {
+				var got []string
+
+				scanner.Scan("c", 3, func(key string) bool {
+					got = append(got, key)
+
+					return true
+				})
+
+				if expected := []string{"c", "c/a", "c/d"}; !slices.Equal(got, expected) {
+					t.Errorf("Scan mismatch: expected %v, got %v", expected, got)
+				}
+			}
+
+			for _, key := range keys {
+				s.Remove(key)
+
+				if s.Contains(key) {
+					t.Fatalf("Contains %q after removing", key)
+				}
+			}
+
+			if r, ok := s.(resetter); ok {
+				for _, key := range keys {
+					s.Insert(key)
+				}
+
+				r.Reset()
+
+				for _, key := range keys {
+					if s.Contains(key) {
+						t.Fatalf("Contains %q after reset", key)
+					}
+				}
+			}
+		})
+	}
+}
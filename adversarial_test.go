@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+func BenchmarkSetsAdversarial(b *testing.B) {
+	g := randomtest.New(b)
+
+	lengths, chars := random.KubernetesNameLengths, random.KubernetesNamesAllowedChars
+
+	must := func(keys []string, err error) []string {
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		return keys
+	}
+
+	workloads := []struct {
+		name string
+		keys []string
+	}{
+		{"hash-prefix-collisions", must(g.HashPrefixCollisions(256, 3, lengths, chars))},
+		{"hash-row-saturation", must(g.HashRowSaturation(lengths, chars))},
+		{"prefix-chain", g.PrefixChain(63, 4, chars)},
+		{"long-common-prefix", must(g.LongCommonPrefix(256, 200, 8, chars))},
+		{"cuckoo-bucket-collisions", must(g.CuckooBucketCollisions(64, 8, lengths, chars))},
+	}
+
+	// Fan-outs just above capacities of ART Node4, Node16 and Node48.
+	for _, fanout := range []int{5, 17, 49} {
+		workloads = append(workloads, struct {
+			name string
+			keys []string
+		}{fmt.Sprintf("node-churn-%d", fanout), must(g.NodeChurn(64, fanout, 8, random.ASCIIPrintableChars))})
+	}
+
+	for _, w := range workloads {
+		absent := must(g.Disjoint(1024, w.keys, lengths, chars))
+
+		b.Run(w.name, func(b *testing.B) {
+			for _, f := range setFactories {
+				b.Run(f.name, func(b *testing.B) {
+					benchmarkSetKeys(b, f.new(len(w.keys)), w.keys, absent)
+				})
+			}
+		})
+	}
+}
@@ -2,17 +2,19 @@ package charbyteshashmatrix
 
 import (
 	"fmt"
-	"math/rand"
 	"testing"
 
 	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
 )
 
 func TestUint64ToHexRunes(t *testing.T) {
 	const iterations = 1000
 
+	g := randomtest.New(t)
+
 	for i := 0; i < iterations; i++ {
-		val := rand.Uint64()
+		val := g.Uint64()
 
 		result := string(uint64ToHexRunes(val))
 		expected := fmt.Sprintf("%x", val)
@@ -24,9 +26,11 @@ func TestUint64ToHexRunes(t *testing.T) {
 }
 
 func BenchmarkToHexRunes(b *testing.B) {
+	g := randomtest.New(b)
+
 	b.ResetTimer()
 	b.Run("Uint64ToHex", func(b *testing.B) {
-		val := rand.Uint64()
+		val := g.Uint64()
 
 		for i := 0; i < b.N; i++ {
 			_ = uint64ToHexRunes(val)
@@ -35,7 +39,7 @@ func BenchmarkToHexRunes(b *testing.B) {
 
 	b.ResetTimer()
 	b.Run("FmtSprintf", func(b *testing.B) {
-		val := rand.Uint64()
+		val := g.Uint64()
 
 		for i := 0; i < b.N; i++ {
 			_ = []rune(fmt.Sprintf("%x", val))
@@ -44,15 +48,16 @@ func BenchmarkToHexRunes(b *testing.B) {
 }
 
 func TestHashMatrix(t *testing.T) {
+	g := randomtest.New(t)
+	lengths := randomtest.Lengths(t, 255)
 	m := NewMatrix()
 
-	tt := make([]string, 128*128)
+	tt, err := g.UniqueStrings(128*128, lengths, random.KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("UniqueStrings returned an error: %v", err)
+	}
 
 	for i := range tt {
-		length := rand.Intn(255) + 1
-
-		tt[i] = random.String(length, random.KubernetesNamesAllowedChars)
-
 		if err := m.Set(tt[i]); err != nil {
 			t.Fatalf("Set returned an error: %v", err)
 		}
@@ -63,7 +68,7 @@ func TestHashMatrix(t *testing.T) {
 	}
 
 	for len(tt) > 0 {
-		i := rand.Intn(len(tt))
+		i := g.IntN(len(tt))
 
 		if err := m.Unset(tt[i]); err != nil {
 			t.Fatalf("Unset returned an error: %v", err)
@@ -82,8 +87,15 @@ func TestHashMatrix(t *testing.T) {
 }
 
 func FuzzHashMatrix(f *testing.F) {
+	g := randomtest.New(f)
+	lengths := randomtest.Lengths(f, 255)
+
 	for i := 0; i < 255; i++ {
-		f.Add(random.String(rand.Intn(255)+1, random.KubernetesNamesAllowedChars))
+		f.Add(g.StringOf(lengths, random.KubernetesNamesAllowedChars))
+	}
+
+	for _, seed := range randomtest.UnicodeSeeds(g, 32, 64) {
+		f.Add(seed)
 	}
 
 	f.Fuzz(func(t *testing.T, original string) {
@@ -125,13 +125,17 @@ func (m *CharMatrix) Set(s []rune) error {
 		return ErrInvalidLength
 	}
 
+	// Characters are validated before any write, so a rejected string does not leave partially set rows.
+	for y, char := range s {
+		if _, err := charToIndex(char); err != nil {
+			return fmt.Errorf("%w %q at position %d", err, char, y)
+		}
+	}
+
 	z := len(s) - 1
 
 	for y, char := range s {
-		x, err := charToIndex(char)
-		if err != nil {
-			return err
-		}
+		x, _ := charToIndex(char)
 
 		(*m)[z][y][x] = true
 	}
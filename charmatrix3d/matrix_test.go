@@ -1,19 +1,22 @@
 package charmatrix3d
 
 import (
-	"math/rand"
+	"errors"
 	"testing"
 
 	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
 )
 
 func TestCharMatrix(t *testing.T) {
+	g := randomtest.New(t)
 	runes := make([][]rune, 128*128)
 	size := len(runes) / 4
+	lengths := randomtest.Lengths(t, size-1)
 	m := NewMatrix(size)
 
 	for i := range runes {
-		runes[i] = random.Runes(rand.Intn(size-1)+1, random.KubernetesNamesAllowedChars)
+		runes[i] = g.Runes(lengths.Length(g), random.KubernetesNamesAllowedChars)
 
 		if err := m.Set(runes[i]); err != nil {
 			t.Fatalf("Set returned an error: %v", err)
@@ -25,7 +28,7 @@ func TestCharMatrix(t *testing.T) {
 	}
 
 	for len(runes) > 0 {
-		i := rand.Intn(len(runes))
+		i := g.IntN(len(runes))
 
 		if err := m.Unset(runes[i]); err != nil {
 			t.Fatalf("Unset returned an error: %v", err)
@@ -43,9 +46,43 @@ func TestCharMatrix(t *testing.T) {
 	}
 }
 
+func TestSetRejectedString(t *testing.T) {
+	m := NewMatrix(8)
+
+	if err := m.Set([]rune("abc_def")); !errors.Is(err, ErrInvalidCharacter) {
+		t.Fatalf("Expected ErrInvalidCharacter, got %v", err)
+	}
+
+	assertEmpty(t, m)
+
+	if m.Contains([]rune("abc")) {
+		t.Fatal("Contains prefix of rejected string")
+	}
+}
+
+// assertEmpty fails if any row of the matrix is set, e.g. after a rejected Set.
+func assertEmpty(t *testing.T, m *CharMatrix) {
+	t.Helper()
+
+	for z := range *m {
+		for y := range (*m)[z] {
+			if m.hasSetCount(z, y) > 0 {
+				t.Fatalf("Row %d of depth %d is set", y, z)
+			}
+		}
+	}
+}
+
 func FuzzCharMatrix(f *testing.F) {
+	g := randomtest.New(f)
+	lengths := randomtest.Lengths(f, 255)
+
 	for i := 0; i < 255; i++ {
-		f.Add(random.String(rand.Intn(255)+1, random.KubernetesNamesAllowedChars))
+		f.Add(g.StringOf(lengths, random.KubernetesNamesAllowedChars))
+	}
+
+	for _, seed := range randomtest.UnicodeSeeds(g, 32, 64) {
+		f.Add(seed)
 	}
 
 	f.Fuzz(func(t *testing.T, original string) {
@@ -54,7 +91,14 @@ func FuzzCharMatrix(f *testing.F) {
 		runes := []rune(original)
 
 		if err := m.Set(runes); err != nil {
-			t.Fatalf("Set returned an error: %v", err)
+			// Unicode seeds are mostly outside of Kubernetes name characters.
+			if !errors.Is(err, ErrInvalidCharacter) && !errors.Is(err, ErrInvalidLength) {
+				t.Fatalf("Set returned an unexpected error: %v", err)
+			}
+
+			assertEmpty(t, m)
+
+			return
 		}
 
 		if !m.Contains(runes) {
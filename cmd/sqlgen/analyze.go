@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Param is a positional parameter of a query.
+type Param struct {
+	Name, Type string
+}
+
+// Column is a result column of a query.
+type Column struct {
+	Name, Field, Type string
+}
+
+// Analyzed is a query with Go types of its parameters and result columns.
+type Analyzed struct {
+	Query
+
+	Params  []Param
+	Columns []Column
+}
+
+// analyzer prepares queries against an in-memory database of the schema.
+type analyzer struct {
+	db *sql.DB
+
+	// columns are declared types of columns by table.
+	columns map[string]map[string]string
+}
+
+func newAnalyzer(schema string) (*analyzer, error) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+
+	// Every connection opens its own in-memory database, so the pool is limited to the one with the schema.
+	db.SetMaxOpenConns(1)
+
+	a := &analyzer{db: db, columns: make(map[string]map[string]string)}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("could not create schema: %w", err)
+	}
+
+	if err := a.loadColumns(); err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (a *analyzer) Close() error {
+	return a.db.Close()
+}
+
+func (a *analyzer) loadColumns() error {
+	rows, err := a.db.Query("SELECT m.name, p.name, p.type FROM sqlite_master m, pragma_table_info(m.name) p WHERE m.type = 'table'")
+	if err != nil {
+		return fmt.Errorf("could not read schema: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table, column, declType string
+		if err := rows.Scan(&table, &column, &declType); err != nil {
+			return err
+		}
+
+		table, column = strings.ToLower(table), strings.ToLower(column)
+
+		if a.columns[table] == nil {
+			a.columns[table] = make(map[string]string)
+		}
+
+		a.columns[table][column] = declType
+	}
+
+	return rows.Err()
+}
+
+var (
+	insertPattern     = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+(\w+)\s*\(([^)]*)\)\s*VALUES\s*\(`)
+	tablePattern      = regexp.MustCompile(`(?i)\b(?:FROM|UPDATE|INTO)\s+(\w+)`)
+	comparisonPattern = regexp.MustCompile(`(?i)(\w+)\s*(?:=|==|!=|<>|<=|>=|<|>|\bLIKE|\bGLOB|\bIS)\s*$`)
+	limitPattern      = regexp.MustCompile(`(?i)\b(LIMIT|OFFSET)\s*$`)
+)
+
+// placeholders returns offsets of '?' in SQL outside of string literals, quoted identifiers and comments.
+func placeholders(query string) ([]int, error) {
+	var offsets []int
+
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; c {
+		case '\'', '"', '`':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				return nil, errors.New("unterminated quote")
+			}
+
+			i += end + 1
+		case '-':
+			if strings.HasPrefix(query[i:], "--") {
+				if end := strings.IndexByte(query[i:], '\n'); end >= 0 {
+					i += end
+				} else {
+					i = len(query)
+				}
+			}
+		case ':', '@', '$':
+			if i+1 < len(query) && isWordByte(query[i+1]) {
+				return nil, fmt.Errorf("named parameter at offset %d is not supported, use ?", i)
+			}
+		case '?':
+			if i+1 < len(query) && '0' <= query[i+1] && query[i+1] <= '9' {
+				return nil, fmt.Errorf("numbered parameter at offset %d is not supported, use ?", i)
+			}
+
+			offsets = append(offsets, i)
+		}
+	}
+
+	return offsets, nil
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// goType maps a declared SQLite type to a Go type by SQLite type affinity rules,
+// NOT NULL is not tracked, so scanning NULL fails.
+func goType(declType string) string {
+	t := strings.ToUpper(declType)
+
+	switch {
+	case strings.Contains(t, "INT"):
+		return "int64"
+	case strings.Contains(t, "CHAR"), strings.Contains(t, "CLOB"), strings.Contains(t, "TEXT"):
+		return "string"
+	case strings.Contains(t, "BLOB"):
+		return "[]byte"
+	case strings.Contains(t, "REAL"), strings.Contains(t, "FLOA"), strings.Contains(t, "DOUB"):
+		return "float64"
+	case strings.Contains(t, "BOOL"):
+		return "bool"
+	default:
+		return "any"
+	}
+}
+
+// identifier returns a Go identifier of a column name, exported or not, with ID as an initialism.
+func identifier(column string, exported bool) string {
+	var sb strings.Builder
+
+	for i, part := range strings.FieldsFunc(column, func(r rune) bool { return !isWordByte(byte(r)) || r == '_' }) {
+		switch {
+		case strings.EqualFold(part, "id") && (exported || i > 0):
+			sb.WriteString("ID")
+		case i == 0 && !exported:
+			sb.WriteString(strings.ToLower(part))
+		default:
+			sb.WriteString(strings.ToUpper(part[:1]) + strings.ToLower(part[1:]))
+		}
+	}
+
+	name := sb.String()
+
+	if name == "" || !token.IsIdentifier(name) {
+		return ""
+	}
+
+	return name
+}
+
+// columnType returns declared type of the column in the table, or in the only table that has the column.
+func (a *analyzer) columnType(table, column string) (string, bool) {
+	column = strings.ToLower(column)
+
+	if declType, ok := a.columns[strings.ToLower(table)][column]; ok {
+		return declType, true
+	}
+
+	var (
+		declType string
+		found    int
+	)
+
+	for _, columns := range a.columns {
+		if t, ok := columns[column]; ok {
+			declType = t
+			found++
+		}
+	}
+
+	return declType, found == 1
+}
+
+// params infers name and type of every placeholder from the column it is inserted into or compared with.
+func (a *analyzer) params(query string, offsets []int) []Param {
+	params := make([]Param, len(offsets))
+
+	var table string
+	if m := tablePattern.FindStringSubmatch(query); m != nil {
+		table = m[1]
+	}
+
+	var insertColumns []string
+
+	insertEnd := -1
+
+	if m := insertPattern.FindStringSubmatchIndex(query); m != nil {
+		table = query[m[2]:m[3]]
+		insertEnd = m[1]
+
+		for _, column := range strings.Split(query[m[4]:m[5]], ",") {
+			insertColumns = append(insertColumns, strings.TrimSpace(column))
+		}
+	}
+
+	for i, offset := range offsets {
+		var column string
+
+		before := query[:offset]
+
+		switch {
+		case insertEnd >= 0 && offset >= insertEnd && i < len(insertColumns) && !strings.Contains(query[insertEnd:offset], ")"):
+			column = insertColumns[i]
+		case comparisonPattern.MatchString(before):
+			column = comparisonPattern.FindStringSubmatch(before)[1]
+		case limitPattern.MatchString(before):
+			params[i] = Param{Name: strings.ToLower(limitPattern.FindStringSubmatch(before)[1]), Type: "int64"}
+
+			continue
+		}
+
+		params[i] = Param{Name: identifier(column, false), Type: "any"}
+
+		if declType, ok := a.columnType(table, column); ok {
+			params[i].Type = goType(declType)
+		}
+
+		if params[i].Name == "" || token.IsKeyword(params[i].Name) {
+			params[i].Name = fmt.Sprintf("arg%d", i+1)
+		}
+	}
+
+	// Parameters of the same column, e.g. a range, are numbered.
+	used := make(map[string]int)
+
+	for _, p := range params {
+		used[p.Name]++
+	}
+
+	seen := make(map[string]int)
+
+	for i, p := range params {
+		if used[p.Name] > 1 {
+			seen[p.Name]++
+			params[i].Name = fmt.Sprintf("%s%d", p.Name, seen[p.Name])
+		}
+	}
+
+	return params
+}
+
+// analyze prepares the query, verifies number of its parameters and reads declared types of its result columns.
+func (a *analyzer) analyze(q Query) (Analyzed, error) {
+	result := Analyzed{Query: q}
+
+	offsets, err := placeholders(q.SQL)
+	if err != nil {
+		return result, fmt.Errorf("query %s: %w", q.Name, err)
+	}
+
+	conn, err := a.db.Conn(context.Background())
+	if err != nil {
+		return result, err
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(dc any) error {
+		stmt, err := dc.(*sqlite3.SQLiteConn).Prepare(q.SQL)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		if n := stmt.NumInput(); n != len(offsets) {
+			return fmt.Errorf("prepared statement has %d parameters, found %d placeholders", n, len(offsets))
+		}
+
+		result.Params = a.params(q.SQL, offsets)
+
+		if q.Kind == Exec {
+			return nil
+		}
+
+		// Declared types are known after prepare, the query runs with NULL parameters against empty tables.
+		rows, err := stmt.Query(make([]driver.Value, len(offsets)))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		declTypes := rows.(*sqlite3.SQLiteRows).DeclTypes()
+
+		for i, name := range rows.Columns() {
+			c := Column{Name: name, Field: identifier(name, true), Type: goType(declTypes[i])}
+
+			if c.Field == "" {
+				return fmt.Errorf("result column %q has no Go name, use AS", name)
+			}
+
+			result.Columns = append(result.Columns, c)
+		}
+
+		if len(result.Columns) == 0 {
+			return fmt.Errorf("%s query returns no columns", q.Kind)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("query %s: %w", q.Name, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io"
+	"strings"
+	"text/template"
+)
+
+var codeTemplate = template.Must(template.New("code").Funcs(template.FuncMap{
+	"lower":   func(s string) string { return strings.ToLower(s[:1]) + s[1:] },
+	"comment": func(lines []string) string { return "// " + strings.Join(lines, "\n// ") },
+}).Parse(`// Code generated by sqlgen; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DBTX is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Queries executes the queries on a database or a transaction.
+type Queries struct {
+	db DBTX
+}
+
+// New returns Queries executing on db.
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+{{range .Queries}}{{$q := .}}
+const {{lower .Name}}SQL = {{printf "%q" .SQL}}
+{{- if gt (len .Columns) 1}}
+
+// {{.Name}}Row is a result row of {{.Name}}.
+type {{.Name}}Row struct {
+{{- range .Columns}}
+	{{.Field}} {{.Type}}
+{{- end}}
+}
+{{- end}}
+
+// {{.Name}}Query returns SQL and arguments of {{.Name}}.
+func {{.Name}}Query({{template "params" .}}) (string, []any) {
+	return {{lower .Name}}SQL, []any{ {{- template "args" .}}}
+}
+
+{{if .Doc}}{{comment .Doc}}{{else}}// {{.Name}} executes {{lower .Name}}SQL.{{end}}
+func (q *Queries) {{.Name}}(ctx context.Context{{if .Params}}, {{template "params" .}}{{end}}) ({{template "result" .}}, error) {
+{{- if eq .Kind ":exec"}}
+	return q.db.ExecContext(ctx, {{lower .Name}}SQL{{if .Params}}, {{template "args" .}}{{end}})
+{{- else if eq .Kind ":one"}}
+	var v {{template "row" .}}
+
+	err := q.db.QueryRowContext(ctx, {{lower .Name}}SQL{{if .Params}}, {{template "args" .}}{{end}}).Scan({{template "dest" .}})
+
+	return v, err
+{{- else}}
+	rows, err := q.db.QueryContext(ctx, {{lower .Name}}SQL{{if .Params}}, {{template "args" .}}{{end}})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []{{template "row" .}}
+
+	for rows.Next() {
+		var v {{template "row" .}}
+
+		if err := rows.Scan({{template "dest" .}}); err != nil {
+			return nil, err
+		}
+
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+{{- end}}
+}
+{{end}}
+{{- define "params"}}{{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}} {{$p.Type}}{{end}}{{end}}
+{{- define "args"}}{{range $i, $p := .Params}}{{if $i}}, {{end}}{{$p.Name}}{{end}}{{end}}
+{{- define "row"}}{{if gt (len .Columns) 1}}{{.Name}}Row{{else}}{{(index .Columns 0).Type}}{{end}}{{end}}
+{{- define "dest"}}{{if gt (len .Columns) 1}}{{range $i, $c := .Columns}}{{if $i}}, {{end}}&v.{{$c.Field}}{{end}}{{else}}&v{{end}}{{end}}
+{{- define "result"}}{{if eq .Kind ":exec"}}sql.Result{{else if eq .Kind ":one"}}{{template "row" .}}{{else}}[]{{template "row" .}}{{end}}{{end}}
+`))
+
+// generate writes formatted Go source of package pkg with typed functions of the queries.
+func generate(w io.Writer, pkg string, queries []Analyzed) error {
+	var buf bytes.Buffer
+
+	err := codeTemplate.Execute(&buf, struct {
+		Package string
+		Queries []Analyzed
+	}{pkg, queries})
+	if err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+
+	return err
+}
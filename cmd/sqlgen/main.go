@@ -0,0 +1,106 @@
+// Command sqlgen generates typed Go functions from named queries of .sql files, e.g.
+//
+//	-- name: SelectID :one
+//	SELECT id FROM benchmark WHERE name = ?;
+//
+// generates SelectIDQuery(name string) (string, []any) and (*Queries).SelectID(ctx, name string) (int64, error).
+// Queries are prepared against an in-memory SQLite database of the schema, which validates them and declares types
+// of result columns, types of ? parameters are types of columns they are inserted into or compared with.
+//
+//	sqlgen -schema schema.sql -package queries -o queries.sql.go queries.sql...
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+)
+
+func main() {
+	var (
+		schema = flag.String("schema", "", "SQL file with the schema the queries are prepared against")
+		pkg    = flag.String("package", "queries", "package of the generated code")
+		output = flag.String("o", "", "output file, standard output by default")
+	)
+
+	log.SetFlags(0)
+	log.SetPrefix("sqlgen: ")
+
+	flag.Parse()
+
+	if *schema == "" || flag.NArg() == 0 {
+		log.Fatal("usage: sqlgen -schema schema.sql [-package name] [-o output.go] queries.sql...")
+	}
+
+	src, err := run(*schema, *pkg, flag.Args())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Nothing is written unless all queries are valid.
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*output, src, 0o644)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run returns code of package pkg generated from queries of the files prepared against the schema.
+func run(schemaPath, pkg string, paths []string) ([]byte, error) {
+	schema, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := newAnalyzer(string(schema))
+	if err != nil {
+		return nil, err
+	}
+	defer a.Close()
+
+	var analyzed []Analyzed
+
+	for _, path := range paths {
+		queries, err := readQueries(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, q := range queries {
+			result, err := a.analyze(q)
+			if err != nil {
+				return nil, err
+			}
+
+			analyzed = append(analyzed, result)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := generate(&buf, pkg, analyzed); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func readQueries(path string) ([]Query, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	queries, err := parseQueries(f)
+	if err != nil {
+		return nil, &os.PathError{Op: "parse", Path: path, Err: err}
+	}
+
+	return queries, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/token"
+	"io"
+	"strings"
+)
+
+// Kind is the annotation of a query telling how many rows it returns.
+type Kind string
+
+const (
+	One  Kind = ":one"
+	Many Kind = ":many"
+	Exec Kind = ":exec"
+)
+
+// Query is a named query of a .sql file, e.g.
+//
+//	-- name: GetByName :one
+//	-- GetByName returns id of the row.
+//	SELECT id FROM benchmark WHERE name = ?;
+//
+// Comment lines right after the annotation are its doc comment.
+type Query struct {
+	Name string
+	Kind Kind
+	Doc  []string
+	SQL  string
+}
+
+const namePrefix = "-- name:"
+
+// parseQueries reads named queries, every statement must follow a `-- name: <Name> <:one|:many|:exec>` annotation.
+func parseQueries(r io.Reader) ([]Query, error) {
+	var (
+		queries []Query
+		sql     strings.Builder
+		names   = make(map[string]bool)
+		s       = bufio.NewScanner(r)
+	)
+
+	finish := func() error {
+		if len(queries) == 0 {
+			return nil
+		}
+
+		q := &queries[len(queries)-1]
+		q.SQL = strings.TrimSuffix(strings.TrimSpace(sql.String()), ";")
+		sql.Reset()
+
+		if q.SQL == "" {
+			return fmt.Errorf("query %s has no SQL", q.Name)
+		}
+
+		return nil
+	}
+
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+
+		switch {
+		case strings.HasPrefix(text, namePrefix):
+			if err := finish(); err != nil {
+				return nil, err
+			}
+
+			fields := strings.Fields(strings.TrimPrefix(text, namePrefix))
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: expected `%s <Name> <:one|:many|:exec>`", line, namePrefix)
+			}
+
+			name, kind := fields[0], Kind(fields[1])
+
+			if !token.IsIdentifier(name) || !token.IsExported(name) {
+				return nil, fmt.Errorf("line %d: query name %q is not an exported Go identifier", line, name)
+			}
+
+			if kind != One && kind != Many && kind != Exec {
+				return nil, fmt.Errorf("line %d: unknown query kind %q", line, kind)
+			}
+
+			if names[name] {
+				return nil, fmt.Errorf("line %d: duplicate query %s", line, name)
+			}
+
+			names[name] = true
+
+			queries = append(queries, Query{Name: name, Kind: kind})
+
+		case strings.HasPrefix(text, "--"):
+			// Comments before SQL of a query document it, others are ignored.
+			if len(queries) > 0 && sql.Len() == 0 {
+				q := &queries[len(queries)-1]
+				q.Doc = append(q.Doc, strings.TrimSpace(strings.TrimPrefix(text, "--")))
+			}
+
+		case text == "":
+
+		default:
+			if len(queries) == 0 {
+				return nil, fmt.Errorf("line %d: SQL outside of a named query", line)
+			}
+
+			sql.WriteString(s.Text())
+			sql.WriteByte('\n')
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
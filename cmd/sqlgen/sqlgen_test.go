@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSchema = `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email VARCHAR(255), score REAL, avatar BLOB, created_at DATETIME)`
+
+func TestParseQueries(t *testing.T) {
+	queries, err := parseQueries(strings.NewReader(`-- schema comments are ignored
+-- name: GetUser :one
+-- GetUser returns a user by id.
+SELECT name FROM users
+WHERE id = ?;
+
+-- name: DeleteUsers :exec
+DELETE FROM users;
+`))
+	if err != nil {
+		t.Fatalf("could not parse queries: %v", err)
+	}
+
+	expected := []Query{
+		{Name: "GetUser", Kind: One, Doc: []string{"GetUser returns a user by id."}, SQL: "SELECT name FROM users\nWHERE id = ?"},
+		{Name: "DeleteUsers", Kind: Exec, SQL: "DELETE FROM users"},
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Fatalf("unexpected queries %+v", queries)
+	}
+}
+
+func TestParseQueriesErrors(t *testing.T) {
+	for _, tt := range []struct {
+		sql, err string
+	}{
+		{"SELECT 1;", "SQL outside of a named query"},
+		{"-- name: Get\nSELECT 1;", "expected `-- name: <Name> <:one|:many|:exec>`"},
+		{"-- name: get :one\nSELECT 1;", "not an exported Go identifier"},
+		{"-- name: Get :all\nSELECT 1;", "unknown query kind"},
+		{"-- name: Get :one\nSELECT 1;\n-- name: Get :one\nSELECT 2;", "duplicate query Get"},
+		{"-- name: Get :one\n-- name: List :many\nSELECT 1;", "query Get has no SQL"},
+	} {
+		if _, err := parseQueries(strings.NewReader(tt.sql)); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("expected error %q parsing %q, got %v", tt.err, tt.sql, err)
+		}
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	offsets, err := placeholders("SELECT '?', \"?\" FROM t -- ?\nWHERE a = ? AND b IN (?, ?)")
+	if err != nil {
+		t.Fatalf("could not find placeholders: %v", err)
+	}
+
+	if expected := []int{38, 50, 53}; !reflect.DeepEqual(offsets, expected) {
+		t.Fatalf("unexpected offsets %v, expected %v", offsets, expected)
+	}
+
+	for _, query := range []string{"SELECT :id", "SELECT @id", "SELECT $1", "SELECT ?1", "SELECT 'a"} {
+		if _, err := placeholders(query); err == nil {
+			t.Errorf("expected error for %q", query)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	for _, tt := range []struct {
+		column               string
+		exported, unexported string
+	}{
+		{"id", "ID", "id"},
+		{"user_id", "UserID", "userID"},
+		{"created_at", "CreatedAt", "createdAt"},
+		{"value1", "Value1", "value1"},
+		{"count(*)", "Count", "count"},
+		{"1st", "", ""},
+	} {
+		if actual := identifier(tt.column, true); actual != tt.exported {
+			t.Errorf("exported identifier of %q is %q, expected %q", tt.column, actual, tt.exported)
+		}
+
+		if actual := identifier(tt.column, false); actual != tt.unexported {
+			t.Errorf("unexported identifier of %q is %q, expected %q", tt.column, actual, tt.unexported)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	a, err := newAnalyzer(testSchema)
+	if err != nil {
+		t.Fatalf("could not create analyzer: %v", err)
+	}
+	defer a.Close()
+
+	for _, tt := range []struct {
+		query   Query
+		params  []Param
+		columns []Column
+	}{
+		{
+			Query{Name: "Insert", Kind: Exec, SQL: "INSERT INTO users(name, email, avatar) VALUES(?, ?, ?)"},
+			[]Param{{"name", "string"}, {"email", "string"}, {"avatar", "[]byte"}},
+			nil,
+		},
+		{
+			Query{Name: "Range", Kind: Many, SQL: "SELECT id, score AS points, created_at FROM users WHERE score >= ? AND score < ? AND name LIKE ? LIMIT ? OFFSET ?"},
+			[]Param{{"score1", "float64"}, {"score2", "float64"}, {"name", "string"}, {"limit", "int64"}, {"offset", "int64"}},
+			[]Column{{"id", "ID", "int64"}, {"points", "Points", "float64"}, {"created_at", "CreatedAt", "any"}},
+		},
+		{
+			Query{Name: "Count", Kind: One, SQL: "SELECT count(*) AS total FROM users WHERE id IN (?, ?)"},
+			[]Param{{"arg1", "any"}, {"arg2", "any"}},
+			[]Column{{"total", "Total", "any"}},
+		},
+	} {
+		result, err := a.analyze(tt.query)
+		if err != nil {
+			t.Fatalf("could not analyze %s: %v", tt.query.Name, err)
+		}
+
+		if !reflect.DeepEqual(result.Params, tt.params) {
+			t.Errorf("unexpected params of %s %v, expected %v", tt.query.Name, result.Params, tt.params)
+		}
+
+		if !reflect.DeepEqual(result.Columns, tt.columns) {
+			t.Errorf("unexpected columns of %s %v, expected %v", tt.query.Name, result.Columns, tt.columns)
+		}
+	}
+
+	for _, q := range []Query{
+		{Name: "Unknown", Kind: One, SQL: "SELECT id FROM accounts"},
+		{Name: "NoColumns", Kind: Many, SQL: "DELETE FROM users"},
+		{Name: "Unnamed", Kind: One, SQL: "SELECT 1"},
+	} {
+		if _, err := a.analyze(q); err == nil {
+			t.Errorf("expected error analyzing %s", q.Name)
+		}
+	}
+}
+
+// TestGeneratedQueries fails when db/queries is out of date, it is regenerated by `go generate ./db/queries`.
+func TestGeneratedQueries(t *testing.T) {
+	const dir = "../../db/queries/"
+
+	src, err := run(dir+"schema.sql", "queries", []string{dir + "queries.sql"})
+	if err != nil {
+		t.Fatalf("could not generate queries: %v", err)
+	}
+
+	generated, err := os.ReadFile(dir + "queries.sql.go")
+	if err != nil {
+		t.Fatalf("could not read generated queries: %v", err)
+	}
+
+	if !bytes.Equal(src, generated) {
+		t.Fatal("db/queries/queries.sql.go is out of date, run go generate ./db/queries")
+	}
+}
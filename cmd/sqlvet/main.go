@@ -0,0 +1,45 @@
+// Command sqlvet reports SQL built from strings reaching database/sql, it runs standalone or as a vet tool:
+//
+//	go run ./cmd/sqlvet ./...
+//	go build -o sqlvet ./cmd/sqlvet && go vet -vettool=$(pwd)/sqlvet ./...
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"code.local/go-benchmarks/db/sqlvet"
+)
+
+func main() {
+	if len(os.Args) > 1 && strings.HasSuffix(os.Args[len(os.Args)-1], ".cfg") {
+		redirectVetStdout(os.Args[len(os.Args)-1])
+	}
+	singlechecker.Main(sqlvet.Analyzer)
+}
+
+// redirectVetStdout points os.Stdout at the file go vet reads the JSON diagnostics from.
+// Go 1.26 and later pass it as Stdout of the config, and only fail when diagnostics are written there,
+// while the unitchecker of golang.org/x/tools v0.36.0 writes them to the standard output.
+func redirectVetStdout(cfgPath string) {
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var cfg struct{ Stdout string }
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		log.Fatalf("cannot decode JSON config file %s: %v", cfgPath, err)
+	}
+	if cfg.Stdout == "" {
+		return
+	}
+	f, err := os.Create(cfg.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout = f
+}
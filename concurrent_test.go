@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xxhash "github.com/cespare/xxhash/v2"
+
+	"code.local/go-benchmarks/histogram"
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+// Sets that are safe for concurrent use without external locking.
+var concurrentSetFactories = []setFactory{
+	{"Workiva/go-datastructures/trie/ctrie", newCtrieSet, sizingIgnored},
+	{"alphadose/haxmap", newHaxmapSet, sizingHint},
+	{"sync/map", newSyncMapSet, sizingIgnored},
+	{"sync/rwmutex-map", newRWMutexMapSet, sizingHint},
+	{"local/sharded-map", newShardedMapSet, sizingHint},
+}
+
+// lockedSetFactories returns remaining sets from setFactories guarded by a single mutex.
+func lockedSetFactories() []setFactory {
+	native := make(map[string]bool, len(concurrentSetFactories))
+	for _, f := range concurrentSetFactories {
+		native[f.name] = true
+	}
+
+	var factories []setFactory
+
+	for _, f := range setFactories {
+		if native[f.name] {
+			continue
+		}
+
+		newSet := f.new
+
+		factories = append(factories, setFactory{
+			name: "mutex/" + f.name,
+			new: func(capacity int) set {
+				return &lockedSet{s: newSet(capacity)}
+			},
+			sizing: f.sizing,
+		})
+	}
+
+	return factories
+}
+
+type syncMapSet struct{ m sync.Map }
+
+func newSyncMapSet(int) set { return &syncMapSet{} }
+
+func (s *syncMapSet) Insert(key string) { s.m.Store(key, struct{}{}) }
+
+func (s *syncMapSet) Contains(key string) bool {
+	_, ok := s.m.Load(key)
+
+	return ok
+}
+
+func (s *syncMapSet) Remove(key string) { s.m.Delete(key) }
+
+type rwMutexMapSet struct {
+	mu sync.RWMutex
+	m  map[string]struct{}
+}
+
+func newRWMutexMapSet(capacity int) set {
+	return &rwMutexMapSet{m: make(map[string]struct{}, capacity)}
+}
+
+func (s *rwMutexMapSet) Insert(key string) {
+	s.mu.Lock()
+	s.m[key] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *rwMutexMapSet) Contains(key string) bool {
+	s.mu.RLock()
+	_, ok := s.m[key]
+	s.mu.RUnlock()
+
+	return ok
+}
+
+func (s *rwMutexMapSet) Remove(key string) {
+	s.mu.Lock()
+	delete(s.m, key)
+	s.mu.Unlock()
+}
+
+const shardsCount = 32
+
+// shardedMapSet splits keys over independently locked runtime maps by xxhash of the key.
+type shardedMapSet [shardsCount]rwMutexMapSet
+
+func newShardedMapSet(capacity int) set {
+	var s shardedMapSet
+
+	for i := range s {
+		s[i].m = make(map[string]struct{}, capacity/shardsCount+1)
+	}
+
+	return &s
+}
+
+func (s *shardedMapSet) shard(key string) *rwMutexMapSet {
+	return &s[xxhash.Sum64String(key)%shardsCount]
+}
+
+func (s *shardedMapSet) Insert(key string)        { s.shard(key).Insert(key) }
+func (s *shardedMapSet) Contains(key string) bool { return s.shard(key).Contains(key) }
+func (s *shardedMapSet) Remove(key string)        { s.shard(key).Remove(key) }
+
+// lockedSet uses exclusive lock for reads too, lookups of some wrapped structures are not read-only.
+type lockedSet struct {
+	mu sync.Mutex
+	s  set
+}
+
+func (s *lockedSet) Insert(key string) {
+	s.mu.Lock()
+	s.s.Insert(key)
+	s.mu.Unlock()
+}
+
+func (s *lockedSet) Contains(key string) bool {
+	s.mu.Lock()
+	ok := s.s.Contains(key)
+	s.mu.Unlock()
+
+	return ok
+}
+
+func (s *lockedSet) Remove(key string) {
+	s.mu.Lock()
+	s.s.Remove(key)
+	s.mu.Unlock()
+}
+
+func BenchmarkSetsParallel(b *testing.B) {
+	const keyCount = 1 << 12
+
+	g := randomtest.New(b)
+
+	keys := make([]string, keyCount)
+	for i := range keys {
+		keys[i] = ycsbKey(i)
+	}
+
+	factories := append(append([]setFactory{}, concurrentSetFactories...), lockedSetFactories()...)
+
+	for _, procs := range []int{1, 2, 4, 8, 16} {
+		for _, readPercent := range []int{100, 90, 50} {
+			for _, hot := range []bool{false, true} {
+				contention := "uniform"
+				if hot {
+					contention = "hot-keys"
+				}
+
+				name := fmt.Sprintf("procs=%d/reads=%d%%/%s", procs, readPercent, contention)
+
+				b.Run(name, func(b *testing.B) {
+					defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+
+					for _, f := range factories {
+						b.Run(f.name, func(b *testing.B) {
+							s := f.new(keyCount)
+
+							for _, key := range keys {
+								s.Insert(key)
+							}
+
+							var (
+								goroutineSeed atomic.Int64
+								latencyMu     sync.Mutex
+								latency       = histogram.New()
+							)
+
+							b.ResetTimer()
+							b.RunParallel(func(pb *testing.PB) {
+								r := g.Stream(uint64(goroutineSeed.Add(1)))
+								zipf := rand.NewZipf(r.Rand, 1.1, 1, keyCount-1)
+
+								var (
+									start            time.Time
+									goroutineLatency = histogram.New()
+								)
+
+								defer func() {
+									latencyMu.Lock()
+									latency.Merge(goroutineLatency)
+									latencyMu.Unlock()
+								}()
+
+								for pb.Next() {
+									var key string
+									if hot {
+										key = keys[zipf.Uint64()]
+									} else {
+										key = keys[r.IntN(keyCount)]
+									}
+
+									op := r.IntN(100)
+
+									if *latencyFlag {
+										start = time.Now()
+									}
+
+									// Writes are split evenly between removals and re-insertions,
+									// so the set stays about the same size for the whole run.
+									switch {
+									case op < readPercent:
+										_ = s.Contains(key)
+									case op%2 == 0:
+										s.Remove(key)
+									default:
+										s.Insert(key)
+									}
+
+									if *latencyFlag {
+										goroutineLatency.RecordDuration(time.Since(start))
+									}
+								}
+							})
+
+							reportLatency(b, latency)
+						})
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestConcurrentSets(t *testing.T) {
+	const (
+		workers       = 8
+		keysPerWorker = 256
+	)
+
+	for _, f := range append(append([]setFactory{}, concurrentSetFactories...), lockedSetFactories()...) {
+		t.Run(f.name, func(t *testing.T) {
+			s := f.new(workers * keysPerWorker)
+
+			var wg sync.WaitGroup
+
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+
+				go func(w int) {
+					defer wg.Done()
+
+					for i := 0; i < keysPerWorker; i++ {
+						s.Insert(ycsbKey(w*keysPerWorker + i))
+					}
+				}(w)
+			}
+
+			wg.Wait()
+
+			for i := 0; i < workers*keysPerWorker; i++ {
+				if !s.Contains(ycsbKey(i)) {
+					t.Fatalf("Does not contain %q after concurrent inserts", ycsbKey(i))
+				}
+			}
+		})
+	}
+}
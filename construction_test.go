@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/armon/go-radix"
+	"github.com/snorwin/gorax"
+
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+// Sets with native bulk constructors, both libraries build from a map of all keys, which is built before the timer.
+var bulkSetFactories = []struct {
+	name string
+	load func(m map[string]interface{}) set
+}{
+	{"snorwin/gorax", func(m map[string]interface{}) set { return &goraxSet{t: gorax.FromMap(m)} }},
+	{"armon/go-radix", func(m map[string]interface{}) set { return &armonRadixSet{r: radix.NewFromMap(m)} }},
+}
+
+func BenchmarkSetsConstruction(b *testing.B) {
+	g := randomtest.New(b)
+
+	for _, size := range []int{1 << 10, 1 << 14} {
+		shuffled := make([]string, size)
+		for i := range shuffled {
+			shuffled[i] = ycsbKey(i)
+		}
+
+		g.Shuffle(len(shuffled), func(i, j int) {
+			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		})
+
+		sorted := slices.Clone(shuffled)
+		slices.Sort(sorted)
+
+		load := func(b *testing.B, s set, keys []string) {
+			for _, key := range keys {
+				s.Insert(key)
+			}
+
+			if !s.Contains(keys[len(keys)-1]) {
+				b.FailNow()
+			}
+		}
+
+		// Empty sets grow while keys are inserted, fixed capacity sets can not grow and are left out.
+		b.Run(fmt.Sprintf("size=%d/grow", size), func(b *testing.B) {
+			for _, f := range setFactories {
+				b.Run(f.name, func(b *testing.B) {
+					if f.sizing == sizingFixed {
+						b.Skip("fixed capacity structure")
+					}
+
+					b.ReportAllocs()
+
+					for i := 0; i < b.N; i++ {
+						load(b, f.new(0), shuffled)
+					}
+				})
+			}
+		})
+
+		b.Run(fmt.Sprintf("size=%d/presized", size), func(b *testing.B) {
+			for _, f := range setFactories {
+				b.Run(f.name, func(b *testing.B) {
+					if f.sizing == sizingIgnored {
+						b.Skip("structure can not be pre-sized")
+					}
+
+					b.ReportAllocs()
+
+					for i := 0; i < b.N; i++ {
+						load(b, f.new(size), shuffled)
+					}
+				})
+			}
+		})
+
+		b.Run(fmt.Sprintf("size=%d/sorted", size), func(b *testing.B) {
+			for _, f := range setFactories {
+				b.Run(f.name, func(b *testing.B) {
+					b.ReportAllocs()
+
+					for i := 0; i < b.N; i++ {
+						load(b, f.new(size), sorted)
+					}
+				})
+			}
+		})
+
+		bulk := make(map[string]interface{}, size)
+		for _, key := range sorted {
+			bulk[key] = struct{}{}
+		}
+
+		b.Run(fmt.Sprintf("size=%d/bulk", size), func(b *testing.B) {
+			for _, f := range bulkSetFactories {
+				b.Run(f.name, func(b *testing.B) {
+					b.ReportAllocs()
+
+					for i := 0; i < b.N; i++ {
+						if !f.load(bulk).Contains(sorted[size-1]) {
+							b.FailNow()
+						}
+					}
+				})
+			}
+		})
+
+		// Reused sets are reset and loaded again, so only re-insertion into already allocated memory is measured.
+		b.Run(fmt.Sprintf("size=%d/reset", size), func(b *testing.B) {
+			for _, f := range setFactories {
+				b.Run(f.name, func(b *testing.B) {
+					s := f.new(size)
+
+					r, ok := s.(resetter)
+					if !ok {
+						b.Skip("reset is not supported")
+					}
+
+					load(b, s, shuffled)
+
+					b.ReportAllocs()
+					b.ResetTimer()
+
+					for i := 0; i < b.N; i++ {
+						r.Reset()
+
+						if s.Contains(shuffled[0]) {
+							b.Fatalf("Contains %q after reset", shuffled[0])
+						}
+
+						load(b, s, shuffled)
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+func BenchmarkSetsCorpus(b *testing.B) {
+	g := randomtest.New(b)
+
+	for _, ratio := range []float64{0, 0.5, 1} {
+		corpus := random.KubernetesPods(8, 8, 2, 16)
+		corpus.CommonPrefixRatio = ratio
+
+		keys, err := corpus.Generate(g)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		// Keys are generated in depth-first order, shuffling avoids sorted inserts.
+		g.Shuffle(len(keys), func(i, j int) {
+			keys[i], keys[j] = keys[j], keys[i]
+		})
+
+		shared := random.SharedPrefixRatio(keys)
+
+		b.Run(fmt.Sprintf("common-prefix-%v", ratio), func(b *testing.B) {
+			for _, f := range setFactories {
+				b.Run(f.name, func(b *testing.B) {
+					// Corpus keys use only Kubernetes name characters, which every set must keep.
+					if lost := benchmarkSetKeys(b, f.new(len(keys)), keys, nil); lost > 0 {
+						b.Fatalf("lost %d of %d keys", lost, len(keys))
+					}
+
+					b.ReportMetric(shared, "shared-prefix")
+				})
+			}
+		})
+	}
+}
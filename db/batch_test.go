@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	// Default limits of host parameters in a statement, SQLite before 3.32.0 allowed only 999.
+	legacyHostParameterLimit = 999
+	hostParameterLimit       = 32766
+)
+
+// hostParameters returns the limit of host parameters of the database connection.
+func hostParameters(tb testing.TB, db *sql.DB) int {
+	tb.Helper()
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		tb.Fatalf("could not get connection: %v", err)
+	}
+	defer conn.Close()
+
+	limit := hostParameterLimit
+
+	err = conn.Raw(func(dc any) error {
+		if c, ok := dc.(*sqlite3.SQLiteConn); ok {
+			limit = c.GetLimit(sqlite3.SQLITE_LIMIT_VARIABLE_NUMBER)
+		}
+
+		return nil
+	})
+	if err != nil {
+		tb.Fatalf("could not get host parameter limit: %v", err)
+	}
+
+	return limit
+}
+
+// setHostParameters changes the limit of host parameters of the database connection, e.g. to the legacy one.
+func setHostParameters(tb testing.TB, db *sql.DB, limit int) {
+	tb.Helper()
+
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		tb.Fatalf("could not get connection: %v", err)
+	}
+	defer conn.Close()
+
+	err = conn.Raw(func(dc any) error {
+		dc.(*sqlite3.SQLiteConn).SetLimit(sqlite3.SQLITE_LIMIT_VARIABLE_NUMBER, limit)
+
+		return nil
+	})
+	if err != nil {
+		tb.Fatalf("could not set host parameter limit: %v", err)
+	}
+}
+
+// insertChunked inserts rows by multi-row inserts of the strategy, every insert has at most limit host parameters.
+func insertChunked(db querier, s multiRowInserter, rows []benchmarkRow, limit int) error {
+	size := max(limit/len(benchmarkColumns), 1)
+
+	for start := 0; start < len(rows); start += size {
+		query, args, err := s.InsertRows(rows[start:min(start+size, len(rows))])
+		if err != nil {
+			return fmt.Errorf("could not build insert SQL: %w", err)
+		}
+
+		if _, err := db.Exec(query, args...); err != nil {
+			return fmt.Errorf("could not execute insert statement: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// inTx runs f in a transaction, which is rolled back if f fails.
+func inTx(db *sql.DB, f func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return fmt.Errorf("could not begin transaction: %w", err)
+	}
+
+	if err := f(tx); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertSelectSQL generates rows of newBenchmarkRow from the first to the last index inside SQLite,
+// so it has two host parameters regardless of the number of rows.
+const insertSelectSQL = `WITH RECURSIVE seq(i) AS (SELECT ? UNION ALL SELECT i + 1 FROM seq WHERE i < ?)
+INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9)
+SELECT 'Name' || i, i, i + 1, i + 2, i + 3, i + 4, i + 5, i + 6, i + 7, i + 8 FROM seq`
+
+// asMultiRowInserter returns the strategy as a multiRowInserter, strategies without multi-row inserts are skipped.
+func asMultiRowInserter(tb testing.TB, s QueryStrategy) multiRowInserter {
+	tb.Helper()
+
+	m, ok := s.(multiRowInserter)
+	if !ok {
+		tb.Skip("multi-row insert is not supported")
+	}
+
+	return m
+}
+
+func countRows(tb testing.TB, db *sql.DB) int {
+	tb.Helper()
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM benchmark").Scan(&count); err != nil {
+		tb.Fatalf("could not count rows: %v", err)
+	}
+
+	return count
+}
+
+func TestInsertChunked(t *testing.T) {
+	rows := make([]benchmarkRow, 1000)
+	for i := range rows {
+		rows[i] = newBenchmarkRow(i)
+	}
+
+	db := openBenchmarkDB(t)
+	setHostParameters(t, db, legacyHostParameterLimit)
+
+	if err := insertChunked(db, rawStrategy{}, rows, len(rows)*len(benchmarkColumns)); err == nil {
+		t.Fatalf("insert of %d parameters did not fail with limit %d", len(rows)*len(benchmarkColumns), legacyHostParameterLimit)
+	}
+
+	for _, qs := range queryStrategies {
+		t.Run(qs.name, func(t *testing.T) {
+			db := openBenchmarkDB(t)
+			setHostParameters(t, db, legacyHostParameterLimit)
+
+			s := asMultiRowInserter(t, newQueryStrategy(t, qs.name, qs.new))
+
+			limit := hostParameters(t, db)
+
+			err := inTx(db, func(tx *sql.Tx) error {
+				return insertChunked(tx, s, rows, limit)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count := countRows(t, db); count != len(rows) {
+				t.Fatalf("expected %d rows, got %d", len(rows), count)
+			}
+
+			var name string
+			if err := db.QueryRow("SELECT name FROM benchmark WHERE value9 = ?", rows[999].Value9).Scan(&name); err != nil || name != rows[999].Name {
+				t.Fatalf("unexpected last row %q: %v", name, err)
+			}
+		})
+	}
+
+	t.Run("insert-select", func(t *testing.T) {
+		db := openBenchmarkDB(t)
+
+		if _, err := db.Exec(insertSelectSQL, 0, len(rows)-1); err != nil {
+			t.Fatal(err)
+		}
+
+		if count := countRows(t, db); count != len(rows) {
+			t.Fatalf("expected %d rows, got %d", len(rows), count)
+		}
+	})
+}
+
+// BenchmarkSQLiteBatchInsert inserts batches of rows as single-row inserts in a transaction,
+// as multi-row inserts chunked by the host parameter limit in a transaction, and generated by INSERT ... SELECT.
+func BenchmarkSQLiteBatchInsert(b *testing.B) {
+	for _, batch := range []int{1, 10, 100, 1000} {
+		rows := make([]benchmarkRow, batch)
+		for i := range rows {
+			rows[i] = newBenchmarkRow(i)
+		}
+
+		b.Run(fmt.Sprintf("batch-%d", batch), func(b *testing.B) {
+			for _, qs := range queryStrategies {
+				b.Run("tx/"+qs.name, func(b *testing.B) {
+					db := openBenchmarkDB(b)
+					s := newQueryStrategy(b, qs.name, qs.new)
+
+					b.ResetTimer()
+
+					for i := 0; i < b.N; i++ {
+						err := inTx(db, func(tx *sql.Tx) error {
+							for _, row := range rows {
+								query, args, err := s.Insert(row)
+								if err != nil {
+									return fmt.Errorf("could not build insert SQL: %w", err)
+								}
+
+								if _, err := tx.Exec(query, args...); err != nil {
+									return fmt.Errorf("could not execute insert statement: %w", err)
+								}
+							}
+
+							return nil
+						})
+						if err != nil {
+							b.Fatal(err)
+						}
+					}
+
+					b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/row")
+				})
+			}
+
+			for _, qs := range queryStrategies {
+				b.Run("multi-row/"+qs.name, func(b *testing.B) {
+					db := openBenchmarkDB(b)
+					s := asMultiRowInserter(b, newQueryStrategy(b, qs.name, qs.new))
+
+					limit := hostParameters(b, db)
+
+					b.ResetTimer()
+
+					for i := 0; i < b.N; i++ {
+						err := inTx(db, func(tx *sql.Tx) error {
+							return insertChunked(tx, s, rows, limit)
+						})
+						if err != nil {
+							b.Fatal(err)
+						}
+					}
+
+					b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/row")
+				})
+			}
+
+			b.Run("insert-select", func(b *testing.B) {
+				db := openBenchmarkDB(b)
+
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					if _, err := db.Exec(insertSelectSQL, 0, batch-1); err != nil {
+						b.Fatalf("could not execute insert statement: %v", err)
+					}
+				}
+
+				b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*batch), "ns/row")
+			})
+		})
+	}
+}
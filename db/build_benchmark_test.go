@@ -0,0 +1,62 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// BenchmarkQueryBuild builds SQL and arguments of all four steps without any database,
+// so allocations of query builders are not hidden by SQLite execution.
+func BenchmarkQueryBuild(b *testing.B) {
+	row := newBenchmarkRow(1)
+
+	for _, qs := range queryStrategies {
+		b.Run(qs.name, func(b *testing.B) {
+			s := newQueryStrategy(b, qs.name, qs.new)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, _, err := s.Insert(row); err != nil {
+					b.Fatalf("could not build insert SQL: %v", err)
+				}
+
+				if _, _, err := s.SelectID(row.Name); err != nil {
+					b.Fatalf("could not build select SQL: %v", err)
+				}
+
+				if _, _, err := s.Update(2, row.Value1); err != nil {
+					b.Fatalf("could not build update SQL: %v", err)
+				}
+
+				if _, _, err := s.SelectValue(2); err != nil {
+					b.Fatalf("could not build select SQL: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkNoopDriverInsertSelectUpdate executes the steps against a driver that does nothing,
+// so it measures query building and database/sql overhead separately from SQLite.
+func BenchmarkNoopDriverInsertSelectUpdate(b *testing.B) {
+	for _, qs := range queryStrategies {
+		b.Run(qs.name, func(b *testing.B) {
+			db, err := sql.Open("noop", "")
+			if err != nil {
+				b.Fatalf("could not open noop database: %v", err)
+			}
+			defer db.Close()
+
+			s := newQueryStrategy(b, qs.name, qs.new)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				executeSteps(b, db, s, i)
+			}
+		})
+	}
+}
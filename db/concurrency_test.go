@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+const (
+	minBackoff = 50 * time.Microsecond
+	maxBackoff = 10 * time.Millisecond
+	maxRetries = 10
+)
+
+// busyStats counts SQLITE_BUSY and SQLITE_LOCKED errors of concurrent workers
+// and operations that still failed after maxRetries.
+type busyStats struct {
+	ops, busy, locked, failed atomic.Int64
+}
+
+// busyCode returns SQLITE_BUSY or SQLITE_LOCKED code of the error.
+func busyCode(err error) (sqlite3.ErrNo, bool) {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return 0, false
+	}
+
+	return sqliteErr.Code, sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+}
+
+// retryBusy runs f until it does not fail with SQLITE_BUSY or SQLITE_LOCKED, sleeping with exponential backoff
+// between attempts, jitter spreads retries of workers that failed at the same time.
+func retryBusy(stats *busyStats, jitter func(n int64) int64, f func() error) error {
+	stats.ops.Add(1)
+
+	backoff := minBackoff
+
+	for attempt := 0; ; attempt++ {
+		err := f()
+
+		code, ok := busyCode(err)
+		if !ok {
+			return err
+		}
+
+		if code == sqlite3.ErrBusy {
+			stats.busy.Add(1)
+		} else {
+			stats.locked.Add(1)
+		}
+
+		if attempt == maxRetries {
+			stats.failed.Add(1)
+
+			return err
+		}
+
+		time.Sleep(backoff/2 + time.Duration(jitter(int64(backoff/2))))
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// openWALDB opens a WAL-mode database file with the benchmark table, busyTimeout 0 makes SQLite
+// return SQLITE_BUSY immediately instead of waiting for the lock.
+func openWALDB(tb testing.TB, busyTimeout time.Duration) *sql.DB {
+	tb.Helper()
+
+	m := fileMode{journalMode: "WAL", synchronous: "NORMAL", cacheSize: -2000}
+
+	db := openBenchmarkDSN(tb, m.dsn(filepath.Join(tb.TempDir(), "benchmark.db"))+fmt.Sprintf("&_busy_timeout=%d", busyTimeout.Milliseconds()))
+	createBenchmarkTable(tb, db)
+
+	return db
+}
+
+func TestRetryBusy(t *testing.T) {
+	db := openWALDB(t, 0)
+	db.SetMaxOpenConns(2)
+
+	// Transaction of the first connection holds the write lock, so inserts of the second one are busy until it commits.
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatalf("could not get connection: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(context.Background(), "BEGIN IMMEDIATE"); err != nil {
+		t.Fatalf("could not begin transaction: %v", err)
+	}
+
+	time.AfterFunc(5*time.Millisecond, func() {
+		conn.ExecContext(context.Background(), "COMMIT")
+	})
+
+	var stats busyStats
+
+	query, args, _ := rawStrategy{}.Insert(newBenchmarkRow(0))
+
+	err = retryBusy(&stats, func(n int64) int64 { return n }, func() error {
+		_, err := db.Exec(query, args...)
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("insert failed after retries: %v", err)
+	}
+
+	if stats.busy.Load() == 0 || stats.failed.Load() != 0 {
+		t.Fatalf("unexpected %d busy errors and %d failed operations", stats.busy.Load(), stats.failed.Load())
+	}
+
+	if count := countRows(t, db); count != 1 {
+		t.Fatalf("expected 1 row, got %d", count)
+	}
+}
+
+// BenchmarkSQLiteConcurrentReadersWriters runs readers selecting random rows and writers inserting rows
+// in parallel against a WAL-mode database file, with different connection pools and busy timeouts.
+// Busy and locked errors are retried with backoff, `busy/op` and `locked/op` count them and `failed/op` counts operations
+// that failed after all retries.
+func BenchmarkSQLiteConcurrentReadersWriters(b *testing.B) {
+	const rows = 1000
+
+	g := randomtest.New(b)
+
+	workloads := []struct {
+		readers, writers int
+	}{
+		{8, 1},
+		{1, 1},
+		{1, 8},
+	}
+
+	pools := []struct {
+		maxOpen, maxIdle int
+	}{
+		{1, 1},
+		{4, 4},
+		{16, 2},
+	}
+
+	for _, w := range workloads {
+		for _, pool := range pools {
+			for _, busyTimeout := range []time.Duration{0, 5 * time.Second} {
+				name := fmt.Sprintf("readers-%d/writers-%d/max-open-%d/max-idle-%d/busy-timeout-%v", w.readers, w.writers, pool.maxOpen, pool.maxIdle, busyTimeout)
+
+				b.Run(name, func(b *testing.B) {
+					db := openWALDB(b, busyTimeout)
+
+					initial := make([]benchmarkRow, rows)
+					for i := range initial {
+						initial[i] = newBenchmarkRow(i)
+					}
+
+					if err := insertChunked(db, rawStrategy{}, initial, hostParameters(b, db)); err != nil {
+						b.Fatal(err)
+					}
+
+					db.SetMaxOpenConns(pool.maxOpen)
+					db.SetMaxIdleConns(pool.maxIdle)
+
+					var (
+						stats     busyStats
+						goroutine atomic.Int64
+					)
+
+					// Every goroutine is a reader or a writer, so their ratio is kept for any GOMAXPROCS.
+					b.SetParallelism(w.readers + w.writers)
+					b.ResetTimer()
+
+					b.RunParallel(func(pb *testing.PB) {
+						id := goroutine.Add(1)
+						r := g.Stream(uint64(id))
+						writer := int(id)%(w.readers+w.writers) < w.writers
+
+						for i := 0; pb.Next(); i++ {
+							var err error
+
+							if writer {
+								query, args, _ := rawStrategy{}.Insert(newBenchmarkRow(rows + i))
+
+								err = retryBusy(&stats, r.Int64N, func() error {
+									_, err := db.Exec(query, args...)
+
+									return err
+								})
+							} else {
+								query, args, _ := rawStrategy{}.SelectValue(r.IntN(rows) + 1)
+
+								err = retryBusy(&stats, r.Int64N, func() error {
+									var val float64
+
+									return db.QueryRow(query, args...).Scan(&val)
+								})
+							}
+
+							// Operations failed after retries are counted, any other error is unexpected.
+							if _, ok := busyCode(err); err != nil && !ok {
+								b.Errorf("unexpected error: %v", err)
+
+								return
+							}
+						}
+					})
+
+					ops := float64(stats.ops.Load())
+
+					b.ReportMetric(ops/b.Elapsed().Seconds(), "ops/s")
+					b.ReportMetric(float64(stats.busy.Load())/ops, "busy/op")
+					b.ReportMetric(float64(stats.locked.Load())/ops, "locked/op")
+					b.ReportMetric(float64(stats.failed.Load())/ops, "failed/op")
+				})
+			}
+		}
+	}
+}
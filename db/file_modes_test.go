@@ -0,0 +1,102 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fileMode is a combination of go-sqlite3 DSN parameters of a file-backed database.
+type fileMode struct {
+	journalMode string
+	synchronous string
+	cacheSize   int // pages if positive, KiB if negative
+}
+
+func (m fileMode) String() string {
+	return fmt.Sprintf("journal=%s/synchronous=%s/cache=%d", m.journalMode, m.synchronous, m.cacheSize)
+}
+
+func (m fileMode) dsn(path string) string {
+	return fmt.Sprintf("file:%s?_journal_mode=%s&_synchronous=%s&_cache_size=%d", path, m.journalMode, m.synchronous, m.cacheSize)
+}
+
+// fileModes returns the matrix of journal modes, synchronous settings and cache sizes,
+// -2000 is the SQLite default of 2 MiB and -65536 is 64 MiB.
+func fileModes() []fileMode {
+	var modes []fileMode
+
+	for _, journalMode := range []string{"DELETE", "WAL", "MEMORY"} {
+		for _, synchronous := range []string{"OFF", "NORMAL", "FULL"} {
+			for _, cacheSize := range []int{-2000, -65536} {
+				modes = append(modes, fileMode{journalMode: journalMode, synchronous: synchronous, cacheSize: cacheSize})
+			}
+		}
+	}
+
+	return modes
+}
+
+// openFileDB opens a database file in a temporary directory with the benchmark table,
+// TMPDIR selects the file system.
+func openFileDB(tb testing.TB, m fileMode) *sql.DB {
+	tb.Helper()
+
+	db := openBenchmarkDSN(tb, m.dsn(filepath.Join(tb.TempDir(), "benchmark.db")))
+	createBenchmarkTable(tb, db)
+
+	return db
+}
+
+func TestFileModes(t *testing.T) {
+	synchronous := map[string]int{"OFF": 0, "NORMAL": 1, "FULL": 2}
+
+	for _, m := range fileModes() {
+		t.Run(m.String(), func(t *testing.T) {
+			db := openFileDB(t, m)
+
+			var (
+				journalMode     string
+				sync, cacheSize int
+			)
+
+			if err := db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+				t.Fatalf("could not get journal mode: %v", err)
+			}
+
+			if err := db.QueryRow("PRAGMA synchronous").Scan(&sync); err != nil {
+				t.Fatalf("could not get synchronous: %v", err)
+			}
+
+			if err := db.QueryRow("PRAGMA cache_size").Scan(&cacheSize); err != nil {
+				t.Fatalf("could not get cache size: %v", err)
+			}
+
+			if !strings.EqualFold(journalMode, m.journalMode) || sync != synchronous[m.synchronous] || cacheSize != m.cacheSize {
+				t.Fatalf("unexpected journal_mode=%s, synchronous=%d, cache_size=%d", journalMode, sync, cacheSize)
+			}
+
+			for i := 0; i < 10; i++ {
+				insertSelectUpdate(t, db, rawStrategy{}, i)
+			}
+		})
+	}
+}
+
+// BenchmarkSQLiteFileInsertSelectUpdate runs raw SQL steps against a database file for every combination of fileModes,
+// every insert and update is a transaction, so it measures the cost of durability.
+func BenchmarkSQLiteFileInsertSelectUpdate(b *testing.B) {
+	for _, m := range fileModes() {
+		b.Run(m.String(), func(b *testing.B) {
+			db := openFileDB(b, m)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				insertSelectUpdate(b, db, rawStrategy{}, i)
+			}
+		})
+	}
+}
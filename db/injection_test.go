@@ -9,6 +9,9 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/cristalhq/builq"
+	"github.com/flosch/pongo2/v6"
+
+	"code.local/go-benchmarks/db/sqltemplate"
 )
 
 func TestSQLInjectionWithSqlmock(t *testing.T) {
@@ -215,3 +218,75 @@ func TestSQLInjectionPreventionUsingBuilq(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLInjectionPreventionUsingSQLTemplate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("An error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	tmpl := sqltemplate.Must(sqltemplate.New("select", "SELECT * FROM {{ident .Table}} WHERE username = {{.User}}", "users"))
+
+	tpl, err := sqltemplate.NewPongo2("SELECT * FROM {% sqlident table %} WHERE username = {% sqlbind user %}", "users")
+	if err != nil {
+		t.Fatalf("could not parse pongo2 template: %v", err)
+	}
+
+	renderers := []struct {
+		name   string
+		render func(table, user string) (string, []any, error)
+	}{
+		{"text/template", func(table, user string) (string, []any, error) {
+			return tmpl.Execute(map[string]string{"Table": table, "User": user})
+		}},
+		{"pongo2", func(table, user string) (string, []any, error) {
+			return tpl.Execute(pongo2.Context{"table": table, "user": user})
+		}},
+	}
+
+	tests := []struct {
+		name  string
+		user  string
+		table string
+	}{
+		{name: "Tautologies", user: "anything' OR 'x'='x", table: "users"},
+		{name: "Illegal/Logically Incorrect Queries", user: "admin' AND 1=2 UNION SELECT * FROM users --", table: "users"},
+		{name: "Union Query", user: "admin' UNION SELECT * FROM users --", table: "users"},
+		{name: "Piggy-Backed Queries", user: "admin'; DROP TABLE users; --", table: "users"},
+		{name: "FmtSprintf Injection", user: "'; DROP TABLE users; --", table: "users"},
+		{name: "TableName Injection", user: "admin", table: "users; DROP TABLE sensitive_data; --"},
+	}
+
+	for _, r := range renderers {
+		for _, tc := range tests {
+			t.Run(r.name+"/"+tc.name, func(t *testing.T) {
+				query, args, err := r.render(tc.table, tc.user)
+				if tc.table != "users" {
+					// Identifiers are not placeholders, so only allowlisted ones are rendered.
+					if !errors.Is(err, sqltemplate.ErrIdentNotAllowed) {
+						t.Fatalf("expected identifier error, got %v", err)
+					}
+
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("could not render query: %v", err)
+				}
+
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM users WHERE username = ?")).
+					WithArgs(tc.user).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "username"}))
+
+				if _, err := db.Query(query, args...); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Errorf("There were unfulfilled expectations: %s", err)
+				}
+			})
+		}
+	}
+}
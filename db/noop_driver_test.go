@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+)
+
+// noopDriver accepts any statement without parsing or executing it, so benchmarks using it measure
+// query building and database/sql overhead only. Every query returns a single row with a single zero value.
+type noopDriver struct{}
+
+func init() {
+	sql.Register("noop", noopDriver{})
+}
+
+func (noopDriver) Open(string) (driver.Conn, error) {
+	return noopConn{}, nil
+}
+
+// noopConn implements ExecerContext and QueryerContext, as the SQLite driver does, so statements are not prepared.
+type noopConn struct{}
+
+func (noopConn) Prepare(string) (driver.Stmt, error) {
+	return noopStmt{}, nil
+}
+
+func (noopConn) Close() error {
+	return nil
+}
+
+func (noopConn) Begin() (driver.Tx, error) {
+	return noopTx{}, nil
+}
+
+func (noopConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (noopConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &noopRows{}, nil
+}
+
+type noopStmt struct{}
+
+func (noopStmt) Close() error {
+	return nil
+}
+
+func (noopStmt) NumInput() int {
+	return -1
+}
+
+func (noopStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (noopStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &noopRows{}, nil
+}
+
+type noopTx struct{}
+
+func (noopTx) Commit() error {
+	return nil
+}
+
+func (noopTx) Rollback() error {
+	return nil
+}
+
+type noopRows struct {
+	done bool
+}
+
+func (*noopRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (*noopRows) Close() error {
+	return nil
+}
+
+func (r *noopRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+
+	r.done = true
+	dest[0] = int64(0)
+
+	return nil
+}
@@ -0,0 +1,11 @@
+// Package queries contains typed functions of queries.sql generated by cmd/sqlgen against schema.sql.
+package queries
+
+import _ "embed"
+
+//go:generate go run ../../cmd/sqlgen -schema schema.sql -package queries -o queries.sql.go queries.sql
+
+// Schema creates the benchmark table.
+//
+//go:embed schema.sql
+var Schema string
@@ -0,0 +1,16 @@
+-- name: Insert :exec
+INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: SelectID :one
+-- SelectID returns id of the row with the name.
+SELECT id FROM benchmark WHERE name = ?;
+
+-- name: Update :exec
+UPDATE benchmark SET value1 = ? WHERE id = ?;
+
+-- name: SelectValue :one
+SELECT value1 FROM benchmark WHERE id = ?;
+
+-- name: SelectRecords :many
+-- SelectRecords returns the first rows ordered by id.
+SELECT id, name, value1, value2, value3, value4, value5, value6, value7, value8, value9 FROM benchmark ORDER BY id LIMIT ?;
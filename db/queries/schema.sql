@@ -0,0 +1,13 @@
+CREATE TABLE benchmark (
+	id INTEGER PRIMARY KEY,
+	name TEXT,
+	value1 REAL,
+	value2 REAL,
+	value3 REAL,
+	value4 REAL,
+	value5 REAL,
+	value6 REAL,
+	value7 REAL,
+	value8 REAL,
+	value9 REAL
+);
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"code.local/go-benchmarks/db/queries"
+)
+
+// queriesInsertSelectUpdate executes the steps of executeSteps by typed methods generated by cmd/sqlgen
+// and verifies the results, rows are expected to be inserted in order from 0.
+func queriesInsertSelectUpdate(tb testing.TB, q *queries.Queries, i int) {
+	ctx := context.Background()
+	row := newBenchmarkRow(i)
+
+	_, err := q.Insert(ctx, row.Name, row.Value1, row.Value2, row.Value3, row.Value4, row.Value5, row.Value6, row.Value7, row.Value8, row.Value9)
+	if err != nil {
+		tb.Fatalf("could not execute insert statement: %v", err)
+	}
+
+	id, err := q.SelectID(ctx, row.Name)
+	if err != nil {
+		tb.Fatalf("could not execute select statement: %v", err)
+	}
+
+	if id != int64(i+1) {
+		tb.Fatalf("data mismatch: expected %d, got %d.", i+1, id)
+	}
+
+	if _, err := q.Update(ctx, float64(i+10), id); err != nil {
+		tb.Fatalf("could not execute update statement: %v", err)
+	}
+
+	val, err := q.SelectValue(ctx, id)
+	if err != nil {
+		tb.Fatalf("could not execute select statement: %v", err)
+	}
+
+	if val != float64(i+10) {
+		tb.Fatalf("data mismatch: expected %f, got %f.", float64(i+10), val)
+	}
+}
+
+// selectGeneratedRecords selects the first n records by SelectRecords, its rows are converted to benchmarkRecord,
+// which costs one allocation of the result per query.
+func selectGeneratedRecords(db *sql.DB, n int) ([]benchmarkRecord, error) {
+	rows, err := queries.New(db).SelectRecords(context.Background(), int64(n))
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]benchmarkRecord, len(rows))
+	for i, r := range rows {
+		records[i] = benchmarkRecord(r)
+	}
+
+	return records, nil
+}
+
+// queriesTargets run the typed methods on the database and inside a transaction committed at the end.
+var queriesTargets = []struct {
+	name string
+	run  func(db *sql.DB, f func(q *queries.Queries)) error
+}{
+	{"db", func(db *sql.DB, f func(q *queries.Queries)) error {
+		f(queries.New(db))
+
+		return nil
+	}},
+	{"tx", func(db *sql.DB, f func(q *queries.Queries)) error {
+		return inTx(db, func(tx *sql.Tx) error {
+			f(queries.New(tx))
+
+			return nil
+		})
+	}},
+}
+
+func TestQueries(t *testing.T) {
+	for _, target := range queriesTargets {
+		t.Run(target.name, func(t *testing.T) {
+			db := openBenchmarkDB(t)
+
+			err := target.run(db, func(q *queries.Queries) {
+				for i := 0; i < 10; i++ {
+					queriesInsertSelectUpdate(t, q, i)
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if count := countRows(t, db); count != 10 {
+				t.Fatalf("expected 10 rows, got %d", count)
+			}
+		})
+	}
+}
+
+// BenchmarkSQLiteQueries executes the steps of BenchmarkSQLiteInsertSelectUpdate by typed methods of db/queries
+// on *sql.DB and *sql.Tx, the sqlgen strategy covers only their SQL.
+func BenchmarkSQLiteQueries(b *testing.B) {
+	for _, target := range queriesTargets {
+		b.Run(target.name, func(b *testing.B) {
+			db := openBenchmarkDB(b)
+
+			b.ResetTimer()
+
+			err := target.run(db, func(q *queries.Queries) {
+				for i := 0; i < b.N; i++ {
+					queriesInsertSelectUpdate(b, q, i)
+				}
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
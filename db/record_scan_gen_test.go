@@ -0,0 +1,25 @@
+// Code generated by scan.Generate; DO NOT EDIT.
+
+package main
+
+import "database/sql"
+
+// scanBenchmarkRecords scans all rows into benchmarkRecord, columns are expected in order: id name value1 value2 value3 value4 value5 value6 value7 value8 value9.
+// Rows are closed when it returns.
+func scanBenchmarkRecords(rows *sql.Rows) ([]benchmarkRecord, error) {
+	defer rows.Close()
+
+	var result []benchmarkRecord
+
+	for rows.Next() {
+		var v benchmarkRecord
+
+		if err := rows.Scan(&v.ID, &v.Name, &v.Value1, &v.Value2, &v.Value3, &v.Value4, &v.Value5, &v.Value6, &v.Value7, &v.Value8, &v.Value9); err != nil {
+			return nil, err
+		}
+
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+}
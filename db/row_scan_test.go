@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"code.local/go-benchmarks/db/scan"
+)
+
+var update = flag.Bool("update", false, "regenerate scanners of benchmark records")
+
+// benchmarkRecord is a row of the benchmark table with all columns.
+type benchmarkRecord struct {
+	ID     int64   `db:"id"`
+	Name   string  `db:"name"`
+	Value1 float64 `db:"value1"`
+	Value2 float64 `db:"value2"`
+	Value3 float64 `db:"value3"`
+	Value4 float64 `db:"value4"`
+	Value5 float64 `db:"value5"`
+	Value6 float64 `db:"value6"`
+	Value7 float64 `db:"value7"`
+	Value8 float64 `db:"value8"`
+	Value9 float64 `db:"value9"`
+}
+
+const (
+	selectRecordsSQL = "SELECT id, name, value1, value2, value3, value4, value5, value6, value7, value8, value9 FROM benchmark ORDER BY id LIMIT ?"
+
+	generatedScannerPath = "record_scan_gen_test.go"
+)
+
+func scanRecordsManually(rows *sql.Rows) ([]benchmarkRecord, error) {
+	defer rows.Close()
+
+	var records []benchmarkRecord
+
+	for rows.Next() {
+		var r benchmarkRecord
+
+		err := rows.Scan(&r.ID, &r.Name, &r.Value1, &r.Value2, &r.Value3, &r.Value4, &r.Value5, &r.Value6, &r.Value7, &r.Value8, &r.Value9)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, r)
+	}
+
+	return records, rows.Err()
+}
+
+// selectRecords returns a function selecting the first n records and scanning them by scanRows.
+func selectRecords(scanRows func(rows *sql.Rows) ([]benchmarkRecord, error)) func(db *sql.DB, n int) ([]benchmarkRecord, error) {
+	return func(db *sql.DB, n int) ([]benchmarkRecord, error) {
+		rows, err := db.Query(selectRecordsSQL, n)
+		if err != nil {
+			return nil, fmt.Errorf("could not execute select statement: %w", err)
+		}
+
+		return scanRows(rows)
+	}
+}
+
+// rowScanners are compared by BenchmarkSQLiteScanRows, generated one is regenerated by `go test ./db -run TestGeneratedScanner -update`,
+// sqlgen is SelectRecords of db/queries.
+var rowScanners = []struct {
+	name          string
+	selectRecords func(db *sql.DB, n int) ([]benchmarkRecord, error)
+}{
+	{"manual", selectRecords(scanRecordsManually)},
+	{"reflection", selectRecords(scan.All[benchmarkRecord])},
+	{"generated", selectRecords(scanBenchmarkRecords)},
+	{"sqlgen", selectGeneratedRecords},
+}
+
+// openRecordsDB opens an in-memory database with n rows of newBenchmarkRow.
+func openRecordsDB(tb testing.TB, n int) *sql.DB {
+	tb.Helper()
+
+	db := openBenchmarkDB(tb)
+
+	rows := make([]benchmarkRow, n)
+	for i := range rows {
+		rows[i] = newBenchmarkRow(i)
+	}
+
+	// The limit is read before the transaction takes the only connection of the pool.
+	limit := hostParameters(tb, db)
+
+	err := inTx(db, func(tx *sql.Tx) error {
+		return insertChunked(tx, rawStrategy{}, rows, limit)
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return db
+}
+
+func TestGeneratedScanner(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := scan.Generate(&buf, "main", reflect.TypeFor[benchmarkRecord]()); err != nil {
+		t.Fatalf("could not generate scanner: %v", err)
+	}
+
+	if *update {
+		if err := os.WriteFile(generatedScannerPath, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("could not write scanner: %v", err)
+		}
+	}
+
+	src, err := os.ReadFile(generatedScannerPath)
+	if err != nil {
+		t.Fatalf("could not read scanner: %v", err)
+	}
+
+	if !bytes.Equal(src, buf.Bytes()) {
+		t.Fatalf("%s is out of date, run go test ./db -run TestGeneratedScanner -update", generatedScannerPath)
+	}
+}
+
+func TestRowScanners(t *testing.T) {
+	db := openRecordsDB(t, 100)
+
+	for _, s := range rowScanners {
+		t.Run(s.name, func(t *testing.T) {
+			records, err := s.selectRecords(db, 100)
+			if err != nil {
+				t.Fatalf("could not select records: %v", err)
+			}
+
+			if len(records) != 100 {
+				t.Fatalf("expected 100 records, got %d", len(records))
+			}
+
+			for i, r := range records {
+				row := newBenchmarkRow(i)
+
+				if expected := (benchmarkRecord{int64(i + 1), row.Name, row.Value1, row.Value2, row.Value3, row.Value4, row.Value5, row.Value6, row.Value7, row.Value8, row.Value9}); r != expected {
+					t.Fatalf("unexpected record %+v, expected %+v", r, expected)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkSQLiteScanRows selects all columns of 1, 100 and 10k rows and scans them into structs
+// with manual Scan, the reflection mapper of db/scan, a scanner generated by it and SelectRecords generated by cmd/sqlgen.
+func BenchmarkSQLiteScanRows(b *testing.B) {
+	const maxRows = 10000
+
+	db := openRecordsDB(b, maxRows)
+
+	for _, n := range []int{1, 100, maxRows} {
+		b.Run(fmt.Sprintf("rows-%d", n), func(b *testing.B) {
+			for _, s := range rowScanners {
+				b.Run(s.name, func(b *testing.B) {
+					b.ReportAllocs()
+
+					// Allocations are counted by the runtime, as the benchmark reports only their total per operation.
+					var before, after runtime.MemStats
+					runtime.ReadMemStats(&before)
+					b.ResetTimer()
+
+					for i := 0; i < b.N; i++ {
+						records, err := s.selectRecords(db, n)
+						if err != nil {
+							b.Fatalf("could not select records: %v", err)
+						}
+
+						if len(records) != n {
+							b.Fatalf("data mismatch: expected %d rows, got %d.", n, len(records))
+						}
+					}
+
+					b.StopTimer()
+					runtime.ReadMemStats(&after)
+
+					b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/row")
+					b.ReportMetric(float64(after.Mallocs-before.Mallocs)/float64(b.N*n), "allocs/row")
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package scan
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+var scannerTemplate = template.Must(template.New("scanner").Parse(`// Code generated by scan.Generate; DO NOT EDIT.
+
+package {{.Package}}
+
+import "database/sql"
+
+// {{.Func}} scans all rows into {{.Type}}, columns are expected in order:{{range .Columns}} {{.}}{{end}}.
+// Rows are closed when it returns.
+func {{.Func}}(rows *sql.Rows) ([]{{.Type}}, error) {
+	defer rows.Close()
+
+	var result []{{.Type}}
+
+	for rows.Next() {
+		var v {{.Type}}
+
+		if err := rows.Scan({{range $i, $f := .Fields}}{{if $i}}, {{end}}&v.{{$f}}{{end}}); err != nil {
+			return nil, err
+		}
+
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+}
+`))
+
+// Generate writes Go source of package pkg with function `scan<Type>s`, which scans rows of columns
+// tagged on struct type t without reflection. Columns are in order of fields.
+func Generate(w io.Writer, pkg string, t reflect.Type) error {
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %s", ErrNotStruct, t)
+	}
+
+	// Name of the type is part of the function name, anonymous structs can not be referred to by generated code.
+	if t.Name() == "" {
+		return fmt.Errorf("%w: %s", ErrUnnamedStruct, t)
+	}
+
+	data := struct {
+		Package, Func, Type string
+		Columns, Fields     []string
+	}{
+		Package: pkg,
+		Func:    "scan" + strings.ToUpper(t.Name()[:1]) + t.Name()[1:] + "s",
+		Type:    t.Name(),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if column, ok := f.Tag.Lookup(Tag); ok && f.IsExported() && column != "-" {
+			data.Columns = append(data.Columns, column)
+			data.Fields = append(data.Fields, f.Name)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if err := scannerTemplate.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+
+	return err
+}
@@ -0,0 +1,115 @@
+// Package scan maps rows of database/sql to structs by `db` field tags, either by reflection at run time
+// or by scanners generated for a struct.
+package scan
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrNotStruct     = errors.New("destination is not a struct")
+	ErrUnknownColumn = errors.New("column has no field")
+	ErrUnnamedStruct = errors.New("struct type has no name")
+)
+
+// Tag is the struct tag holding column name of a field, fields without it are not mapped.
+const Tag = "db"
+
+type planKey struct {
+	typ     reflect.Type
+	columns string
+}
+
+// plans caches field indexes of columns by struct type and columns of the query.
+var plans sync.Map // planKey -> []int
+
+// fields returns column names of exported fields of struct type t with their indexes.
+func fields(t reflect.Type) (map[string]int, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrNotStruct, t)
+	}
+
+	columns := make(map[string]int, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		if column, ok := f.Tag.Lookup(Tag); ok && f.IsExported() && column != "-" {
+			columns[column] = i
+		}
+	}
+
+	return columns, nil
+}
+
+func plan(t reflect.Type, columns []string) ([]int, error) {
+	key := planKey{typ: t, columns: strings.Join(columns, ",")}
+
+	if indexes, ok := plans.Load(key); ok {
+		return indexes.([]int), nil
+	}
+
+	fieldIndexes, err := fields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]int, len(columns))
+
+	for i, column := range columns {
+		index, ok := fieldIndexes[column]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of %s", ErrUnknownColumn, column, t)
+		}
+
+		indexes[i] = index
+	}
+
+	plans.Store(key, indexes)
+
+	return indexes, nil
+}
+
+// All scans all rows into structs of type T, every column must have a field tagged with its name.
+// Rows are closed when it returns.
+func All[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	indexes, err := plan(reflect.TypeFor[T](), columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result []T
+		dest   = make([]any, len(columns))
+	)
+
+	for rows.Next() {
+		var v T
+
+		s := reflect.ValueOf(&v).Elem()
+
+		for i, index := range indexes {
+			dest[i] = s.Field(index).Addr().Interface()
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		result = append(result, v)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,111 @@
+package scan
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type user struct {
+	ID       int64  `db:"id"`
+	Name     string `db:"name"`
+	Email    string `db:"email"`
+	Internal string
+	Skipped  string `db:"-"`
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("could not open sqlite3 database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT);
+		INSERT INTO users(name, email) VALUES ('alice', 'alice@example.com'), ('bob', 'bob@example.com')`)
+	if err != nil {
+		t.Fatalf("could not create table: %v", err)
+	}
+
+	return db
+}
+
+func TestAll(t *testing.T) {
+	db := openDB(t)
+
+	// Columns are mapped by name, not by order of fields.
+	for _, query := range []string{"SELECT id, name, email FROM users ORDER BY id", "SELECT email, id, name FROM users ORDER BY id"} {
+		rows, err := db.Query(query)
+		if err != nil {
+			t.Fatalf("could not query: %v", err)
+		}
+
+		users, err := All[user](rows)
+		if err != nil {
+			t.Fatalf("could not scan: %v", err)
+		}
+
+		expected := []user{{1, "alice", "alice@example.com", "", ""}, {2, "bob", "bob@example.com", "", ""}}
+		if !reflect.DeepEqual(users, expected) {
+			t.Fatalf("unexpected users %v", users)
+		}
+	}
+}
+
+func TestAllErrors(t *testing.T) {
+	db := openDB(t)
+
+	rows, err := db.Query("SELECT id, name AS username FROM users")
+	if err != nil {
+		t.Fatalf("could not query: %v", err)
+	}
+
+	if _, err := All[user](rows); !errors.Is(err, ErrUnknownColumn) {
+		t.Fatalf("expected unknown column error, got %v", err)
+	}
+
+	rows, err = db.Query("SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("could not query: %v", err)
+	}
+
+	if _, err := All[int](rows); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("expected not struct error, got %v", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := Generate(&buf, "users", reflect.TypeFor[user]()); err != nil {
+		t.Fatalf("could not generate scanner: %v", err)
+	}
+
+	src := buf.String()
+
+	for _, expected := range []string{
+		"// Code generated by scan.Generate; DO NOT EDIT.",
+		"package users",
+		"func scanUsers(rows *sql.Rows) ([]user, error) {",
+		"rows.Scan(&v.ID, &v.Name, &v.Email)",
+	} {
+		if !strings.Contains(src, expected) {
+			t.Fatalf("generated scanner does not contain %q:\n%s", expected, src)
+		}
+	}
+
+	if err := Generate(&buf, "users", reflect.TypeFor[int]()); !errors.Is(err, ErrNotStruct) {
+		t.Fatalf("expected not struct error, got %v", err)
+	}
+
+	if err := Generate(&buf, "users", reflect.TypeFor[struct{ ID int64 }]()); !errors.Is(err, ErrUnnamedStruct) {
+		t.Fatalf("expected unnamed struct error, got %v", err)
+	}
+}
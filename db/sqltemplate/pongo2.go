@@ -0,0 +1,196 @@
+package sqltemplate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+// bindingKey is the context key of the binding of an execution, which tags read.
+const bindingKey = "_sqltemplate_binding"
+
+// Tags printing placeholders and identifiers.
+const (
+	bindTag  = "sqlbind"
+	identTag = "sqlident"
+)
+
+// pongo2Set bans tags, which print values or load other templates, so only sqlbind and sqlident print values.
+var pongo2Set = pongo2.NewSet("sqltemplate", noLoader{})
+
+func init() {
+	for _, name := range []string{"block", "cycle", "extends", "filter", "firstof", "import", "include", "lorem", "macro", "now", "ssi", "templatetag", "widthratio"} {
+		if err := pongo2Set.BanTag(name); err != nil {
+			panic(err)
+		}
+	}
+
+	// Tags are registered for all pongo2 templates, so their names are prefixed by the package.
+	for name, f := range map[string]func(*binding, any) (string, error){bindTag: (*binding).bind, identTag: (*binding).ident} {
+		if err := pongo2.RegisterTag(name, valueTagParser(f)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+type noLoader struct{}
+
+func (noLoader) Abs(base, name string) string { return name }
+
+func (noLoader) Get(path string) (io.Reader, error) {
+	return nil, errors.New("sqltemplate does not load templates")
+}
+
+// valueTagNode prints a value of its expression by f, e.g. {% sqlbind row.name %} or {% sqlident column %}.
+type valueTagNode struct {
+	token *pongo2.Token
+	expr  pongo2.IEvaluator
+	f     func(*binding, any) (string, error)
+}
+
+func valueTagParser(f func(*binding, any) (string, error)) pongo2.TagParser {
+	return func(doc *pongo2.Parser, start *pongo2.Token, arguments *pongo2.Parser) (pongo2.INodeTag, *pongo2.Error) {
+		expr, err := arguments.ParseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		if arguments.Remaining() > 0 {
+			return nil, arguments.Error("Expected a single expression.", nil)
+		}
+
+		return &valueTagNode{token: start, expr: expr, f: f}, nil
+	}
+}
+
+func (n *valueTagNode) Execute(ctx *pongo2.ExecutionContext, w pongo2.TemplateWriter) *pongo2.Error {
+	b, ok := ctx.Public[bindingKey].(*binding)
+	if !ok {
+		return ctx.Error("template is not executed by sqltemplate", n.token)
+	}
+
+	v, perr := n.expr.Evaluate(ctx)
+	if perr != nil {
+		return perr
+	}
+
+	s, err := n.f(b, v.Interface())
+	if err != nil {
+		return ctx.OrigError(err, n.token)
+	}
+
+	if _, err := w.WriteString(s); err != nil {
+		return ctx.OrigError(err, n.token)
+	}
+
+	return nil
+}
+
+// checkQuotedBind fails if a sqlbind tag is inside a string literal, quotes are counted in text outside
+// of tags and comments from the start of the template, as pongo2 has no tree of text nodes to walk.
+func checkQuotedBind(text string) error {
+	quoted := false
+
+	for {
+		i := strings.Index(text, "{")
+		if i < 0 || i == len(text)-1 {
+			return nil
+		}
+
+		if strings.Count(text[:i], "'")%2 == 1 {
+			quoted = !quoted
+		}
+
+		var end string
+
+		switch text[i+1] {
+		case '%':
+			end = "%}"
+		case '#':
+			end = "#}"
+		default:
+			text = text[i+1:]
+
+			continue
+		}
+
+		j := strings.Index(text[i:], end)
+		if j < 0 {
+			return nil // unclosed tags are parse errors of pongo2
+		}
+
+		tag := strings.Fields(strings.TrimLeft(text[i+2:i+j], "-"))
+		if quoted && end == "%}" && len(tag) > 0 && tag[0] == bindTag {
+			return fmt.Errorf("%w: placeholder of %s is quoted", ErrUnsafeOutput, strings.Join(tag, " "))
+		}
+
+		text = text[i+j+len(end):]
+	}
+}
+
+// Pongo2Template is a pongo2 template, which prints values only by tags, e.g.
+//
+//	SELECT {% sqlident column %} FROM benchmark WHERE name = {% sqlbind name %}
+//
+// Variables {{ ... }} are rejected, as they interpolate values, and so are sqlbind tags inside string literals.
+type Pongo2Template struct {
+	tpl    *pongo2.Template
+	idents map[string]bool
+}
+
+// NewPongo2 parses the template text, identifiers are values allowed by the sqlident tag.
+func NewPongo2(text string, identifiers ...string) (*Pongo2Template, error) {
+	if strings.Contains(text, "{{") {
+		return nil, fmt.Errorf("%w: use {%% %s %%} instead of {{ }}", ErrUnsafeOutput, bindTag)
+	}
+
+	if err := checkQuotedBind(text); err != nil {
+		return nil, err
+	}
+
+	tpl, err := pongo2Set.FromString(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pongo2Template{tpl: tpl, idents: allowlist(identifiers)}, nil
+}
+
+// Execute returns SQL of the template applied to the context and arguments of its placeholders.
+func (t *Pongo2Template) Execute(ctx pongo2.Context) (string, []any, error) {
+	b := &binding{idents: t.idents}
+
+	// The context is copied, as the binding of the execution is added to it.
+	c := make(pongo2.Context, len(ctx)+1)
+	maps.Copy(c, ctx)
+	c[bindingKey] = b
+
+	query, err := t.tpl.Execute(c)
+	if err != nil {
+		var perr *pongo2.Error
+		if errors.As(err, &perr) {
+			return "", nil, pongo2Error{perr: perr}
+		}
+
+		return "", nil, err
+	}
+
+	return query, b.args, nil
+}
+
+// pongo2Error unwraps to the original error of a tag, such as ErrIdentNotAllowed.
+type pongo2Error struct {
+	perr *pongo2.Error
+}
+
+func (e pongo2Error) Error() string {
+	return e.perr.Error()
+}
+
+func (e pongo2Error) Unwrap() error {
+	return e.perr.OrigError
+}
@@ -0,0 +1,65 @@
+// Package sqltemplate renders SQL from text/template and pongo2 templates without interpolating values:
+// every printed value becomes a ? placeholder and is appended to arguments of the query.
+// Identifiers, which cannot be placeholders, are printed only by ident and only if they are allowlisted.
+package sqltemplate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrIdentNotAllowed = errors.New("identifier is not allowed")
+	ErrEmptySlice      = errors.New("empty slice has no placeholders")
+	ErrUnsafeOutput    = errors.New("template prints values without bind")
+)
+
+// binding collects arguments of placeholders of one execution.
+type binding struct {
+	args   []any
+	idents map[string]bool
+}
+
+// bind appends v to the arguments and returns its placeholder, elements of slices, except []byte,
+// are appended one by one and their placeholders are separated by commas, e.g. for IN lists.
+func (b *binding) bind(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+
+	if k := rv.Kind(); (k != reflect.Slice && k != reflect.Array) || rv.Type().Elem().Kind() == reflect.Uint8 {
+		b.args = append(b.args, v)
+
+		return "?", nil
+	}
+
+	if rv.Len() == 0 {
+		return "", ErrEmptySlice
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		b.args = append(b.args, rv.Index(i).Interface())
+	}
+
+	return strings.Repeat("?, ", rv.Len()-1) + "?", nil
+}
+
+// ident returns v if it is an allowlisted identifier.
+func (b *binding) ident(v any) (string, error) {
+	s, ok := v.(string)
+	if !ok || !b.idents[s] {
+		return "", fmt.Errorf("%w: %v", ErrIdentNotAllowed, v)
+	}
+
+	return s, nil
+}
+
+func allowlist(identifiers []string) map[string]bool {
+	idents := make(map[string]bool, len(identifiers))
+
+	for _, ident := range identifiers {
+		idents[ident] = true
+	}
+
+	return idents
+}
@@ -0,0 +1,201 @@
+package sqltemplate
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/flosch/pongo2/v6"
+)
+
+const injection = "'; DROP TABLE users; --"
+
+func TestTemplate(t *testing.T) {
+	tmpl := Must(New("report", `SELECT {{ident .Column}} FROM users
+{{- with .Filter}} WHERE name = {{.Name}}{{if .IDs}} AND id IN ({{.IDs}}){{end}}{{end}}
+{{- range $i, $o := .Order}}{{if $i}},{{else}} ORDER BY{{end}} {{ident $o}}{{end}} LIMIT {{.Limit | printf "%d"}}`, "name", "email"))
+
+	type filter struct {
+		Name string
+		IDs  []int64
+	}
+
+	for _, tt := range []struct {
+		data  map[string]any
+		query string
+		args  []any
+	}{
+		{
+			map[string]any{"Column": "name", "Filter": filter{Name: injection, IDs: []int64{1, 2}}, "Order": []string{"name", "email"}, "Limit": 10},
+			"SELECT name FROM users WHERE name = ? AND id IN (?, ?) ORDER BY name, email LIMIT ?",
+			[]any{injection, int64(1), int64(2), "10"},
+		},
+		{
+			map[string]any{"Column": "email", "Filter": nil, "Order": nil, "Limit": 1},
+			"SELECT email FROM users LIMIT ?",
+			[]any{"1"},
+		},
+	} {
+		query, args, err := tmpl.Execute(tt.data)
+		if err != nil {
+			t.Fatalf("could not execute template: %v", err)
+		}
+
+		if query != tt.query || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("unexpected query %q with %v, expected %q with %v", query, args, tt.query, tt.args)
+		}
+	}
+
+	for _, data := range []map[string]any{
+		{"Column": "name; DROP TABLE users", "Filter": nil, "Order": nil, "Limit": 1},
+		{"Column": "name", "Filter": nil, "Order": []string{"id"}, "Limit": 1},
+	} {
+		if _, _, err := tmpl.Execute(data); !errors.Is(err, ErrIdentNotAllowed) {
+			t.Errorf("expected identifier error for %v, got %v", data, err)
+		}
+	}
+
+	if _, _, err := tmpl.Execute(map[string]any{"Column": "name"}); err == nil || !strings.Contains(err.Error(), "map has no entry") {
+		t.Errorf("expected missing key error, got %v", err)
+	}
+
+	if _, _, err := tmpl.Execute(map[string]any{"Column": "name", "Filter": filter{Name: "a", IDs: []int64{}}, "Order": nil, "Limit": 1}); err != nil {
+		t.Errorf("empty IDs are false in if, got %v", err)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	for _, text := range []string{
+		"SELECT id FROM users WHERE name = '{{.Name}}'",
+		"SELECT id FROM users WHERE name LIKE '%{{.Name}}%'",
+		"SELECT id FROM users WHERE name = 'it''s {{.Name}}'",
+		"SELECT id FROM users WHERE name = '{{bind .Name}}'",
+		"SELECT id FROM users WHERE name = '{{if .Name}}a{{else}}{{.Name}}{{end}}'",
+		"SELECT id FROM users WHERE {{if .Name}}name = '{{end}}x'",
+		`{{define "name"}}{{.}}{{end}}SELECT id FROM users WHERE name = '{{template "name" .Name}}'`,
+	} {
+		if _, err := New("quoted", text); !errors.Is(err, ErrUnsafeOutput) {
+			t.Errorf("expected unsafe output error for %q, got %v", text, err)
+		}
+	}
+
+	// Quotes are closed before the placeholder, also across actions.
+	for _, text := range []string{
+		"SELECT id FROM users WHERE name = 'it''s' AND id = {{.ID}}",
+		"SELECT id FROM users WHERE kind = '{{ident .Kind}}' AND id = {{.ID}}",
+		"SELECT id FROM users WHERE name LIKE '%' || {{.Name}} || '%'",
+	} {
+		if _, err := New("closed", text, "admin"); err != nil {
+			t.Errorf("unexpected error for %q: %v", text, err)
+		}
+	}
+
+	tmpl := Must(New("in", "SELECT id FROM users WHERE id IN ({{.}})"))
+
+	if _, _, err := tmpl.Execute([]int{}); !errors.Is(err, ErrEmptySlice) {
+		t.Errorf("expected empty slice error, got %v", err)
+	}
+
+	// []byte is a single BLOB value.
+	if query, args, err := tmpl.Execute([]byte("ab")); err != nil || query != "SELECT id FROM users WHERE id IN (?)" || len(args) != 1 {
+		t.Errorf("unexpected query %q with %v: %v", query, args, err)
+	}
+}
+
+func TestTemplateConcurrentExecute(t *testing.T) {
+	tmpl := Must(New("select", "SELECT id FROM users WHERE id = {{.}}"))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_, args, err := tmpl.Execute(i*100 + j)
+				if err != nil || !reflect.DeepEqual(args, []any{i*100 + j}) {
+					t.Errorf("unexpected args %v: %v", args, err)
+
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestPongo2Template(t *testing.T) {
+	tpl, err := NewPongo2(`SELECT {% sqlident column %} FROM users WHERE name = {% sqlbind name %}
+{%- if ids %} AND id IN ({% sqlbind ids %}){% endif %}
+{%- for o in order %}{% if forloop.First %} ORDER BY {% else %}, {% endif %}{% sqlident o %}{% endfor %}`, "name", "email")
+	if err != nil {
+		t.Fatalf("could not parse template: %v", err)
+	}
+
+	query, args, err := tpl.Execute(pongo2.Context{"column": "email", "name": injection, "ids": []int{1, 2}, "order": []string{"name", "email"}})
+	if err != nil {
+		t.Fatalf("could not execute template: %v", err)
+	}
+
+	if expected := "SELECT email FROM users WHERE name = ? AND id IN (?, ?) ORDER BY name, email"; query != expected {
+		t.Errorf("unexpected query %q, expected %q", query, expected)
+	}
+
+	if expected := []any{injection, 1, 2}; !reflect.DeepEqual(args, expected) {
+		t.Errorf("unexpected args %v, expected %v", args, expected)
+	}
+
+	if _, _, err := tpl.Execute(pongo2.Context{"column": "users; DROP TABLE users", "name": "a"}); !errors.Is(err, ErrIdentNotAllowed) {
+		t.Errorf("expected identifier error, got %v", err)
+	}
+}
+
+func TestPongo2TemplateErrors(t *testing.T) {
+	for _, text := range []string{
+		"SELECT id FROM users WHERE name = {{ name }}",
+		"SELECT id FROM users WHERE name = '{% sqlbind name %}'",
+		"SELECT id FROM users WHERE name LIKE '%{% sqlbind name %}%'",
+		"SELECT id FROM users WHERE name = 'it''s {%- sqlbind name %}'",
+		"SELECT id FROM users WHERE name = '{% if name %}a{% else %}{% sqlbind name %}{% endif %}'",
+	} {
+		if _, err := NewPongo2(text); !errors.Is(err, ErrUnsafeOutput) {
+			t.Errorf("expected unsafe output error for %q, got %v", text, err)
+		}
+	}
+
+	// Quotes in tags and comments are not text of the query.
+	for _, text := range []string{
+		"SELECT id FROM users WHERE name = 'it''s' AND id = {% sqlbind id %}",
+		"SELECT id FROM users WHERE {% if kind == 'admin' %}admin{% endif %} AND id = {% sqlbind id %}",
+		"SELECT id FROM users {# don't #} WHERE id = {% sqlbind id %}",
+	} {
+		if _, err := NewPongo2(text); err != nil {
+			t.Errorf("unexpected error for %q: %v", text, err)
+		}
+	}
+
+	for _, text := range []string{
+		"SELECT id FROM users WHERE name = {% firstof name %}",
+		"SELECT id FROM users WHERE name = {% include \"name.sql\" %}",
+		"SELECT id FROM users WHERE name = {% sqlbind name id %}",
+	} {
+		if _, err := NewPongo2(text); err == nil {
+			t.Errorf("expected parse error for %q", text)
+		}
+	}
+
+	// Plain pongo2 has no binding to collect arguments.
+	tpl, err := pongo2.FromString("{% sqlbind name %}")
+	if err != nil {
+		t.Fatalf("could not parse template: %v", err)
+	}
+
+	if _, err := tpl.Execute(pongo2.Context{"name": "a"}); err == nil {
+		t.Error("expected error executing bind without sqltemplate")
+	}
+}
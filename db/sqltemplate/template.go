@@ -0,0 +1,182 @@
+package sqltemplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"text/template"
+	"text/template/parse"
+)
+
+// Template is a text/template, whose actions print placeholders, e.g.
+//
+//	SELECT {{ident .Column}} FROM benchmark WHERE name = {{.Name}} AND id IN ({{.IDs}})
+//
+// renders `SELECT value1 FROM benchmark WHERE name = ? AND id IN (?, ?)` with arguments name and both ids.
+// Parsed actions are rewritten to end with the bind function, as html/template does with its escapers,
+// unless they end with bind or ident already. Placeholders inside string literals, e.g. '{{.Name}}' or
+// LIKE '%{{.Name}}%', are parse errors, bind them as whole values, e.g. LIKE {{.Pattern}}.
+type Template struct {
+	text   *template.Template
+	idents map[string]bool
+
+	// executions are clones of text with bind of their own, so Execute is safe for concurrent use.
+	executions sync.Pool
+}
+
+type execution struct {
+	binding
+
+	text *template.Template
+	buf  bytes.Buffer
+}
+
+// New parses the template text, identifiers are values allowed by ident.
+// Missing map keys are errors, as they would bind NULL.
+func New(name, text string, identifiers ...string) (*Template, error) {
+	t := &Template{idents: allowlist(identifiers)}
+
+	parsed, err := template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+		"bind":  func(any) (string, error) { return "", errors.New("bind called outside of Execute") },
+		"ident": (&binding{idents: t.idents}).ident,
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tmpl := range parsed.Templates() {
+		if tmpl.Tree == nil {
+			continue
+		}
+
+		if err := (&escaper{}).escape(tmpl.Tree.Root); err != nil {
+			return nil, fmt.Errorf("template %s: %w", tmpl.Name(), err)
+		}
+	}
+
+	t.text = parsed
+	t.executions.New = func() any {
+		e := &execution{binding: binding{idents: t.idents}}
+		e.text = template.Must(t.text.Clone()).Funcs(template.FuncMap{"bind": e.bind})
+
+		return e
+	}
+
+	return t, nil
+}
+
+// Must panics if err is not nil, like template.Must.
+func Must(t *Template, err error) *Template {
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// Execute returns SQL of the template applied to data and arguments of its placeholders.
+func (t *Template) Execute(data any) (string, []any, error) {
+	e := t.executions.Get().(*execution)
+	defer t.executions.Put(e)
+
+	e.buf.Reset()
+	e.args = nil
+
+	if err := e.text.Execute(&e.buf, data); err != nil {
+		return "", nil, err
+	}
+
+	return e.buf.String(), e.args, nil
+}
+
+// escaper appends bind to pipelines of actions printing values, conditions of if, range and with are not printed.
+// Quoted tracks whether the text before the current node ends inside a string literal, quotes are counted
+// across all text nodes, so an escaped quote of a literal toggles it twice.
+type escaper struct {
+	quoted bool
+}
+
+func (e *escaper) escape(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+
+		for _, child := range n.Nodes {
+			if err := e.escape(child); err != nil {
+				return err
+			}
+		}
+	case *parse.TextNode:
+		if bytes.Count(n.Text, []byte("'"))%2 == 1 {
+			e.quoted = !e.quoted
+		}
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) > 0 {
+			return nil
+		}
+
+		if e.quoted && !identified(n.Pipe) {
+			return fmt.Errorf("%w: placeholder of %s is quoted", ErrUnsafeOutput, n)
+		}
+
+		if !escaped(n.Pipe) {
+			n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+				NodeType: parse.NodeCommand,
+				Pos:      n.Pos,
+				Args:     []parse.Node{parse.NewIdentifier("bind").SetPos(n.Pos)},
+			})
+		}
+	case *parse.TemplateNode:
+		if e.quoted {
+			return fmt.Errorf("%w: placeholders of %s are quoted", ErrUnsafeOutput, n)
+		}
+	case *parse.IfNode:
+		return e.escapeBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return e.escapeBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return e.escapeBranch(&n.BranchNode)
+	}
+
+	return nil
+}
+
+// escapeBranch requires both lists of a branch to end in the string literal they start in,
+// otherwise text after the branch, or the next iteration of range, would depend on the branch taken.
+func (e *escaper) escapeBranch(n *parse.BranchNode) error {
+	quoted := e.quoted
+
+	for _, list := range []*parse.ListNode{n.List, n.ElseList} {
+		if err := e.escape(list); err != nil {
+			return err
+		}
+
+		if e.quoted != quoted {
+			return fmt.Errorf("%w: string literal of %s is not closed in its branch", ErrUnsafeOutput, n)
+		}
+	}
+
+	return nil
+}
+
+func escaped(pipe *parse.PipeNode) bool {
+	return lastIdentifier(pipe) == "bind" || identified(pipe)
+}
+
+// identified reports whether the pipeline prints an allowlisted identifier, which is not a placeholder.
+func identified(pipe *parse.PipeNode) bool {
+	return lastIdentifier(pipe) == "ident"
+}
+
+func lastIdentifier(pipe *parse.PipeNode) string {
+	last := pipe.Cmds[len(pipe.Cmds)-1]
+
+	if ident, ok := last.Args[0].(*parse.IdentifierNode); ok {
+		return ident.Ident
+	}
+
+	return ""
+}
@@ -0,0 +1,434 @@
+// Package sqlvet defines an analyzer reporting SQL built from strings, which reaches database/sql.
+package sqlvet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `report SQL built from strings reaching database/sql
+
+The sqlvet analyzer reports queries of Exec, Query, QueryRow and Prepare methods and their Context variants
+of *sql.DB, *sql.Tx and *sql.Conn, which are built from values, which are not constants, by fmt.Sprintf,
+string concatenation, Execute of text/template, html/template or pongo2 templates, or %s verbs of builq.
+Values should be arguments of placeholders instead.
+
+Variables, struct fields and results of functions are tainted once any assignment to them is,
+regardless of control flow. Functions returning built SQL are exported as facts, so their calls
+are reported in other packages. Values of builq.Columns are trusted by %s verbs.`
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "sqlvet",
+	Doc:       doc,
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(builtSQL)},
+}
+
+// builtSQL is a fact of functions, whose first result is SQL built from strings.
+type builtSQL struct {
+	Source string
+}
+
+func (*builtSQL) AFact() {}
+
+func (f *builtSQL) String() string {
+	return "builtSQL(" + f.Source + ")"
+}
+
+const (
+	builqPath  = "github.com/cristalhq/builq"
+	pongo2Path = "github.com/flosch/pongo2/v6"
+)
+
+// sinks are methods of database/sql types by the index of their query argument.
+var sinks = map[string]int{
+	"Exec": 0, "Query": 0, "QueryRow": 0, "Prepare": 0,
+	"ExecContext": 1, "QueryContext": 1, "QueryRowContext": 1, "PrepareContext": 1,
+}
+
+type taint struct {
+	pass *analysis.Pass
+
+	// objects are variables and fields holding built SQL, or templates output and builders of it, by its source.
+	objects map[types.Object]string
+	funcs   map[*types.Func]string
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	t := &taint{pass: pass, objects: make(map[types.Object]string), funcs: make(map[*types.Func]string)}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Taint spreads through assignments in any order, so they are visited until nothing changes.
+	for changed := true; changed; {
+		n := len(t.objects) + len(t.funcs)
+
+		for _, file := range pass.Files {
+			for _, decl := range file.Decls {
+				if fd, ok := decl.(*ast.FuncDecl); ok && fd.Body != nil {
+					fn, _ := pass.TypesInfo.Defs[fd.Name].(*types.Func)
+					t.visit(fd.Body, fn)
+				} else {
+					t.visit(decl, nil)
+				}
+			}
+		}
+
+		changed = len(t.objects)+len(t.funcs) != n
+	}
+
+	for fn, source := range t.funcs {
+		pass.ExportObjectFact(fn, &builtSQL{Source: source})
+	}
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+
+		sink, index := t.sink(call)
+		if sink == "" || index >= len(call.Args) {
+			return
+		}
+
+		if source := t.source(call.Args[index]); source != "" {
+			pass.Reportf(call.Args[index].Pos(), "SQL built by %s is passed to %s, pass values as arguments of placeholders", source, sink)
+		}
+	})
+
+	return nil, nil
+}
+
+// visit taints objects assigned in the node, and fn if it returns built SQL. Function literals return their own results.
+func (t *taint) visit(node ast.Node, fn *types.Func) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			t.visit(n.Body, nil)
+
+			return false
+		case *ast.AssignStmt:
+			t.assign(n.Lhs, n.Rhs, n.Tok)
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(n.Names))
+			for i, name := range n.Names {
+				lhs[i] = name
+			}
+
+			t.assign(lhs, n.Values, token.DEFINE)
+		case *ast.CallExpr:
+			t.call(n)
+		case *ast.ReturnStmt:
+			if fn != nil && len(n.Results) > 0 {
+				if source := t.source(n.Results[0]); source != "" {
+					t.taintFunc(fn, source)
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+func (t *taint) assign(lhs, rhs []ast.Expr, tok token.Token) {
+	for i, l := range lhs {
+		var source string
+
+		switch {
+		case len(rhs) == len(lhs):
+			source = t.source(rhs[i])
+
+			if tok == token.ADD_ASSIGN && source == "" && isString(t.pass.TypesInfo.TypeOf(l)) && !t.constant(rhs[i]) {
+				source = "string concatenation"
+			}
+		case len(rhs) == 1 && i == 0:
+			// The first result of a call, e.g. query of builq Build.
+			source = t.source(rhs[0])
+		}
+
+		if source != "" {
+			t.taintObject(l, source)
+		}
+	}
+}
+
+// call taints writers of executed templates and builq builders formatting values by %s.
+func (t *taint) call(call *ast.CallExpr) {
+	fn, _ := typeutil.Callee(t.pass.TypesInfo, call).(*types.Func)
+
+	switch {
+	case (isMethod(fn, "text/template", "Template", "Execute", "ExecuteTemplate") ||
+		isMethod(fn, "html/template", "Template", "Execute", "ExecuteTemplate")) && len(call.Args) > 0:
+		t.taintObject(call.Args[0], "template Execute")
+	case isMethod(fn, builqPath, "Builder", "Addf") && builqUnsafe(t, call):
+		t.taintObject(call.Fun.(*ast.SelectorExpr).X, "builq %s verb")
+	case isNamed(t.pass.TypesInfo.TypeOf(call.Fun), builqPath, "BuildFn") && builqUnsafe(t, call):
+		t.taintObject(call.Fun, "builq %s verb")
+	}
+}
+
+// source returns how the string expression is built from values, which are not constants, or "" if it is not.
+func (t *taint) source(expr ast.Expr) string {
+	expr = ast.Unparen(expr)
+
+	if t.constant(expr) {
+		return ""
+	}
+
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD && isString(t.pass.TypesInfo.TypeOf(e)) {
+			return "string concatenation"
+		}
+	case *ast.Ident, *ast.SelectorExpr:
+		if obj := t.object(e); obj != nil {
+			return t.objects[obj]
+		}
+	case *ast.CallExpr:
+		return t.callSource(e)
+	}
+
+	return ""
+}
+
+func (t *taint) callSource(call *ast.CallExpr) string {
+	info := t.pass.TypesInfo
+
+	if tv, ok := info.Types[call.Fun]; ok && tv.IsType() && len(call.Args) == 1 {
+		// A conversion, e.g. string(b).
+		return t.source(call.Args[0])
+	}
+
+	fn, _ := typeutil.Callee(info, call).(*types.Func)
+	if fn == nil {
+		return ""
+	}
+
+	switch {
+	case fn.Pkg() != nil && fn.Pkg().Path() == "fmt" && strings.HasPrefix(fn.Name(), "Sprint"):
+		args := call.Args
+		if fn.Name() == "Sprintf" && len(args) > 0 {
+			args = args[1:]
+		}
+
+		for _, arg := range args {
+			if !t.constant(arg) {
+				return "fmt." + fn.Name()
+			}
+		}
+
+		return ""
+	case fn.Pkg() != nil && fn.Pkg().Path() == builqPath && fn.Name() == "Q":
+		if builqUnsafe(t, call) {
+			return "builq %s verb"
+		}
+
+		return ""
+	case isMethod(fn, builqPath, "Builder", "Build") || isMethod(fn, builqPath, "BuildFn", "Build"):
+		return t.builderSource(call.Fun.(*ast.SelectorExpr).X)
+	case isMethod(fn, "bytes", "Buffer", "String") || isMethod(fn, "strings", "Builder", "String"):
+		if obj := t.object(call.Fun.(*ast.SelectorExpr).X); obj != nil {
+			return t.objects[obj]
+		}
+
+		return ""
+	case isMethod(fn, pongo2Path, "Template", "Execute"):
+		return "pongo2 Execute"
+	}
+
+	if source, ok := t.funcs[fn]; ok {
+		return source + " in " + fn.Name()
+	}
+
+	var fact builtSQL
+	if t.pass.ImportObjectFact(fn, &fact) {
+		return fact.Source + " in " + fn.Name()
+	}
+
+	return ""
+}
+
+// builderSource returns the source of a builq builder or BuildFn, e.g. builq.New()("... %s", v) or a variable.
+func (t *taint) builderSource(expr ast.Expr) string {
+	expr = ast.Unparen(expr)
+
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		if obj := t.object(expr); obj != nil {
+			return t.objects[obj]
+		}
+
+		return ""
+	}
+
+	fn, _ := typeutil.Callee(t.pass.TypesInfo, call).(*types.Func)
+
+	switch {
+	case isMethod(fn, builqPath, "Builder", "Addf"):
+		if builqUnsafe(t, call) {
+			return "builq %s verb"
+		}
+
+		return t.builderSource(call.Fun.(*ast.SelectorExpr).X)
+	case isNamed(t.pass.TypesInfo.TypeOf(call.Fun), builqPath, "BuildFn"):
+		if builqUnsafe(t, call) {
+			return "builq %s verb"
+		}
+
+		return t.builderSource(call.Fun)
+	}
+
+	return ""
+}
+
+// builqUnsafe reports whether a builq format call formats a value, which is not a constant, by %s.
+func builqUnsafe(t *taint, call *ast.CallExpr) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+
+	tv := t.pass.TypesInfo.Types[call.Args[0]]
+	if tv.Value == nil {
+		return true
+	}
+
+	format, args := constant.StringVal(tv.Value), call.Args[1:]
+
+	for i, arg := 0, 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			continue
+		}
+
+		i++
+
+		switch verb := format[i]; verb {
+		case 's':
+			if call.Ellipsis.IsValid() || arg >= len(args) {
+				return true
+			}
+
+			if !t.constant(args[arg]) && !isNamed(t.pass.TypesInfo.TypeOf(args[arg]), builqPath, "Columns") {
+				return true
+			}
+
+			arg++
+		case '$', '?', '@', 'd':
+			arg++
+		case '+', '#':
+			i++
+			arg++
+		}
+	}
+
+	return false
+}
+
+// sink returns name of the database/sql method called and index of its query argument.
+func (t *taint) sink(call *ast.CallExpr) (string, int) {
+	fn, _ := typeutil.Callee(t.pass.TypesInfo, call).(*types.Func)
+	if fn == nil {
+		return "", 0
+	}
+
+	index, ok := sinks[fn.Name()]
+	if !ok {
+		return "", 0
+	}
+
+	for _, typ := range []string{"DB", "Tx", "Conn"} {
+		if isMethod(fn, "database/sql", typ, fn.Name()) {
+			return fmt.Sprintf("(*sql.%s).%s", typ, fn.Name()), index
+		}
+	}
+
+	return "", 0
+}
+
+func (t *taint) constant(expr ast.Expr) bool {
+	return t.pass.TypesInfo.Types[expr].Value != nil
+}
+
+// object returns the variable or field of an identifier, a selector or an address of them.
+func (t *taint) object(expr ast.Expr) types.Object {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return t.pass.TypesInfo.ObjectOf(e)
+	case *ast.SelectorExpr:
+		return t.pass.TypesInfo.ObjectOf(e.Sel)
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return t.object(e.X)
+		}
+	}
+
+	return nil
+}
+
+func (t *taint) taintObject(expr ast.Expr, source string) {
+	obj := t.object(expr)
+	if obj == nil || obj.Name() == "_" {
+		return
+	}
+
+	if _, ok := t.objects[obj]; !ok {
+		t.objects[obj] = source
+	}
+}
+
+func (t *taint) taintFunc(fn *types.Func, source string) {
+	if _, ok := t.funcs[fn]; !ok {
+		t.funcs[fn] = source
+	}
+}
+
+func isString(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// isMethod reports whether fn is one of the methods of the named type of the package, or of a pointer to it.
+func isMethod(fn *types.Func, pkg, typ string, names ...string) bool {
+	if fn == nil {
+		return false
+	}
+
+	recv := fn.Type().(*types.Signature).Recv()
+	if recv == nil || !isNamed(recv.Type(), pkg, typ) {
+		return false
+	}
+
+	for _, name := range names {
+		if fn.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNamed(typ types.Type, pkg, name string) bool {
+	if typ == nil {
+		return false
+	}
+
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkg && obj.Name() == name
+}
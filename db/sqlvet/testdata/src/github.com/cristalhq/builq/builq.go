@@ -0,0 +1,22 @@
+// Package builq is a stub of github.com/cristalhq/builq with the API the analyzer knows.
+package builq
+
+type constString string
+
+type Columns []string
+
+func (c Columns) String() string { return "" }
+
+type Builder struct{}
+
+func (b *Builder) Addf(format constString, args ...any) *Builder { return b }
+
+func (b *Builder) Build() (query string, args []any, err error) { return "", nil, nil }
+
+type BuildFn func(format constString, args ...any) *Builder
+
+func (q BuildFn) Build() (query string, args []any, err error) { return "", nil, nil }
+
+func New() BuildFn { return func(constString, ...any) *Builder { return &Builder{} } }
+
+func Q(format constString, args ...any) (query string, resArgs []any, err error) { return "", nil, nil }
@@ -0,0 +1,95 @@
+// Package injection has cases of db/injection_test.go, SQL of unsafe ones is built from user input.
+package injection
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"text/template"
+
+	"github.com/cristalhq/builq"
+	"github.com/flosch/pongo2/v6"
+
+	"injection/queries"
+)
+
+const usersTable = "users"
+
+func placeholders(db *sql.DB, user string) {
+	db.Query("SELECT * FROM users WHERE username = ?", user)
+	db.QueryRow("SELECT * FROM "+usersTable+" WHERE username = ?", user)
+	db.Exec(fmt.Sprintf("DELETE FROM %s", usersTable))
+	db.Query(queries.Users(), user)
+}
+
+func fmtSprintfInjection(db *sql.DB, tableName, username string) {
+	query := fmt.Sprintf("SELECT * FROM %s WHERE username = '%s'", tableName, username)
+	db.Query(query) // want `SQL built by fmt.Sprintf is passed to \(\*sql.DB\).Query`
+
+	db.QueryRowContext(context.Background(), fmt.Sprintf("SELECT * FROM users WHERE username = '%s'", username)) // want `SQL built by fmt.Sprintf is passed to \(\*sql.DB\).QueryRowContext`
+}
+
+func tableNameInjection(tx *sql.Tx, tableName, user string) {
+	tx.Exec("DELETE FROM "+tableName+" WHERE username = ?", user) // want `SQL built by string concatenation is passed to \(\*sql.Tx\).Exec`
+
+	query := "SELECT * FROM users"
+	query += " WHERE username = '" + user + "'"
+	tx.Query(query) // want `SQL built by string concatenation is passed to \(\*sql.Tx\).Query`
+
+	tx.Query(queries.ByTable(tableName), user) // want `SQL built by fmt.Sprintf in ByTable is passed to \(\*sql.Tx\).Query`
+}
+
+func builqPlaceholders(db *sql.DB, user string) {
+	query, args, _ := builq.New()("SELECT %s FROM %s WHERE username = %$", builq.Columns{"username"}, "users", user).Build()
+	db.Query(query, args...)
+
+	var bb builq.Builder
+	bb.Addf("SELECT * FROM users")
+	bb.Addf("WHERE username = %$", user)
+
+	query, args, _ = bb.Build()
+	db.Query(query, args...)
+}
+
+func builqInjection(db *sql.DB, tableName, user string) {
+	query, args, _ := builq.New()("SELECT * FROM users WHERE username = '%s'", user).Build()
+	db.Query(query, args...) // want `SQL built by builq %s verb is passed to \(\*sql.DB\).Query`
+
+	bf := builq.New()
+	bf("SELECT * FROM %s", tableName)
+	bf("WHERE username = %$", user)
+
+	query, args, _ = bf.Build()
+	db.Query(query, args...) // want `SQL built by builq %s verb is passed to \(\*sql.DB\).Query`
+
+	query, args, _ = builq.Q("SELECT * FROM %s WHERE username = %$", tableName, user)
+	db.Query(query, args...) // want `SQL built by builq %s verb is passed to \(\*sql.DB\).Query`
+}
+
+type templateStrategy struct {
+	selectID *template.Template
+	buf      bytes.Buffer
+}
+
+func (s *templateStrategy) execute(tmpl *template.Template, data any) (string, error) { // want execute:`builtSQL\(template Execute\)`
+	s.buf.Reset()
+
+	if err := tmpl.Execute(&s.buf, data); err != nil {
+		return "", err
+	}
+
+	return s.buf.String(), nil
+}
+
+func templateInjection(db *sql.DB, s *templateStrategy, tpl *pongo2.Template, name string) {
+	query, _ := s.execute(s.selectID, map[string]any{"Name": name})
+	db.Query(query) // want `SQL built by template Execute in execute is passed to \(\*sql.DB\).Query`
+
+	rendered, _ := tpl.Execute(pongo2.Context{"name": name})
+	db.Query(rendered) // want `SQL built by pongo2 Execute is passed to \(\*sql.DB\).Query`
+}
+
+func prepared(conn *sql.Conn, user string) {
+	conn.PrepareContext(context.Background(), "SELECT * FROM users WHERE username = "+user) // want `SQL built by string concatenation is passed to \(\*sql.Conn\).PrepareContext`
+}
@@ -0,0 +1,12 @@
+// Package queries builds SQL in another package, it is reported by facts of its functions.
+package queries
+
+import "fmt"
+
+func ByTable(table string) string { // want ByTable:`builtSQL\(fmt.Sprintf\)`
+	return fmt.Sprintf("SELECT * FROM %s WHERE username = ?", table)
+}
+
+func Users() string {
+	return "SELECT * FROM users WHERE username = ?"
+}
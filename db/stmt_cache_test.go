@@ -0,0 +1,237 @@
+package main
+
+import (
+	"container/list"
+	"database/sql"
+	"testing"
+)
+
+// stmtCache prepares statements on first use and keeps up to capacity of them, the least recently used
+// statement is closed when the cache is full. Dynamic builders generate the same SQL for every call, so they
+// reuse prepared statements too, while templates inlining values miss on every call. It is not safe for concurrent use.
+type stmtCache struct {
+	db       *sql.DB
+	capacity int
+
+	lru   *list.List // *cachedStmt, most recently used first
+	stmts map[string]*list.Element
+
+	hits, misses int
+}
+
+type cachedStmt struct {
+	query string
+	stmt  *sql.Stmt
+}
+
+func newStmtCache(db *sql.DB, capacity int) *stmtCache {
+	return &stmtCache{
+		db:       db,
+		capacity: capacity,
+		lru:      list.New(),
+		stmts:    make(map[string]*list.Element, capacity),
+	}
+}
+
+func (c *stmtCache) prepare(query string) (*sql.Stmt, error) {
+	if e, ok := c.stmts[query]; ok {
+		c.hits++
+		c.lru.MoveToFront(e)
+
+		return e.Value.(*cachedStmt).stmt, nil
+	}
+
+	c.misses++
+
+	stmt, err := c.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.lru.Len() >= c.capacity {
+		oldest := c.lru.Remove(c.lru.Back()).(*cachedStmt)
+		delete(c.stmts, oldest.query)
+
+		if err := oldest.stmt.Close(); err != nil {
+			stmt.Close()
+
+			return nil, err
+		}
+	}
+
+	c.stmts[query] = c.lru.PushFront(&cachedStmt{query: query, stmt: stmt})
+
+	return stmt, nil
+}
+
+func (c *stmtCache) Exec(query string, args ...any) (sql.Result, error) {
+	stmt, err := c.prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.Exec(args...)
+}
+
+func (c *stmtCache) QueryRow(query string, args ...any) *sql.Row {
+	stmt, err := c.prepare(query)
+	if err != nil {
+		// sql.Row with an error cannot be created outside database/sql, unprepared query reports it on Scan.
+		return c.db.QueryRow(query, args...)
+	}
+
+	return stmt.QueryRow(args...)
+}
+
+// HitRatio returns the proportion of queries that reused a prepared statement.
+func (c *stmtCache) HitRatio() float64 {
+	if c.hits+c.misses == 0 {
+		return 0
+	}
+
+	return float64(c.hits) / float64(c.hits+c.misses)
+}
+
+// Close closes all cached statements.
+func (c *stmtCache) Close() error {
+	var err error
+
+	for e := c.lru.Front(); e != nil; e = e.Next() {
+		if closeErr := e.Value.(*cachedStmt).stmt.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	c.lru.Init()
+	clear(c.stmts)
+
+	return err
+}
+
+func TestStmtCache(t *testing.T) {
+	db := openBenchmarkDB(t)
+
+	c := newStmtCache(db, 2)
+	defer c.Close()
+
+	queries := []string{"SELECT 1", "SELECT 2", "SELECT 1", "SELECT 3", "SELECT 1", "SELECT 2"}
+
+	for _, query := range queries {
+		var v int
+		if err := c.QueryRow(query).Scan(&v); err != nil {
+			t.Fatalf("could not execute %q: %v", query, err)
+		}
+	}
+
+	// "SELECT 3" evicts "SELECT 2", used less recently than "SELECT 1", which is then prepared again.
+	if c.hits != 2 || c.misses != 4 {
+		t.Fatalf("unexpected hits %d and misses %d", c.hits, c.misses)
+	}
+
+	if c.lru.Len() != 2 || c.lru.Front().Value.(*cachedStmt).query != "SELECT 2" {
+		t.Fatalf("unexpected cached statements")
+	}
+
+	if err := c.QueryRow("SELECT FROM").Scan(new(int)); err == nil {
+		t.Fatalf("invalid query did not fail")
+	}
+
+	for _, qs := range queryStrategies {
+		t.Run(qs.name, func(t *testing.T) {
+			db := openBenchmarkDB(t)
+
+			c := newStmtCache(db, 16)
+			defer c.Close()
+
+			s := newQueryStrategy(t, qs.name, qs.new)
+
+			for i := 0; i < 10; i++ {
+				insertSelectUpdate(t, c, s, i)
+			}
+		})
+	}
+}
+
+// BenchmarkSQLitePreparedInsertSelectUpdate prepares raw SQL of every step once, so it is the lower bound
+// of statement caching.
+func BenchmarkSQLitePreparedInsertSelectUpdate(b *testing.B) {
+	db := openBenchmarkDB(b)
+
+	prepare := func(query string) *sql.Stmt {
+		stmt, err := db.Prepare(query)
+		if err != nil {
+			b.Fatalf("could not prepare statement: %v", err)
+		}
+		b.Cleanup(func() { stmt.Close() })
+
+		return stmt
+	}
+
+	var s rawStrategy
+
+	insertSQL, _, _ := s.Insert(benchmarkRow{})
+	selectIDSQL, _, _ := s.SelectID("")
+	updateSQL, _, _ := s.Update(0, 0)
+	selectValueSQL, _, _ := s.SelectValue(0)
+
+	insert, selectID, update, selectValue := prepare(insertSQL), prepare(selectIDSQL), prepare(updateSQL), prepare(selectValueSQL)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		row := newBenchmarkRow(i)
+
+		_, err := insert.Exec(row.values()...)
+		if err != nil {
+			b.Fatalf("could not execute insert statement: %v", err)
+		}
+
+		var id int
+		err = selectID.QueryRow(row.Name).Scan(&id)
+		if err != nil {
+			b.Fatalf("could not execute select statement: %v", err)
+		}
+
+		if id != (i + 1) {
+			b.Fatalf("data mismatch: expected %d, got %d.", i+1, id)
+		}
+
+		_, err = update.Exec(float64(i+10), id)
+		if err != nil {
+			b.Fatalf("could not execute update statement: %v", err)
+		}
+
+		var val float64
+		err = selectValue.QueryRow(id).Scan(&val)
+		if err != nil {
+			b.Fatalf("could not execute select statement: %v", err)
+		}
+
+		if val != float64(i+10) {
+			b.Fatalf("data mismatch: expected %f, got %f.", float64(i+10), val)
+		}
+	}
+}
+
+// BenchmarkSQLiteStmtCacheInsertSelectUpdate executes SQL built by every strategy through the statement cache,
+// `hit-ratio` shows which strategies generate reusable SQL.
+func BenchmarkSQLiteStmtCacheInsertSelectUpdate(b *testing.B) {
+	for _, qs := range queryStrategies {
+		b.Run(qs.name, func(b *testing.B) {
+			db := openBenchmarkDB(b)
+
+			c := newStmtCache(db, 16)
+			defer c.Close()
+
+			s := newQueryStrategy(b, qs.name, qs.new)
+
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				insertSelectUpdate(b, c, s, i)
+			}
+
+			b.ReportMetric(c.HitRatio(), "hit-ratio")
+		})
+	}
+}
@@ -0,0 +1,585 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cristalhq/builq"
+	"github.com/flosch/pongo2/v6"
+	"github.com/keegancsmith/sqlf"
+
+	"code.local/go-benchmarks/db/queries"
+	"code.local/go-benchmarks/db/sqltemplate"
+)
+
+// benchmarkRow is a row of the benchmark table without its id.
+type benchmarkRow struct {
+	Name   string
+	Value1 float64
+	Value2 float64
+	Value3 float64
+	Value4 float64
+	Value5 float64
+	Value6 float64
+	Value7 float64
+	Value8 float64
+	Value9 float64
+}
+
+func newBenchmarkRow(i int) benchmarkRow {
+	return benchmarkRow{
+		Name:   fmt.Sprintf("Name%d", i),
+		Value1: float64(i),
+		Value2: float64(i + 1),
+		Value3: float64(i + 2),
+		Value4: float64(i + 3),
+		Value5: float64(i + 4),
+		Value6: float64(i + 5),
+		Value7: float64(i + 6),
+		Value8: float64(i + 7),
+		Value9: float64(i + 8),
+	}
+}
+
+func (r benchmarkRow) values() []any {
+	return []any{r.Name, r.Value1, r.Value2, r.Value3, r.Value4, r.Value5, r.Value6, r.Value7, r.Value8, r.Value9}
+}
+
+var benchmarkColumns = []string{"name", "value1", "value2", "value3", "value4", "value5", "value6", "value7", "value8", "value9"}
+
+// QueryStrategy builds SQL and its arguments for every step of the insert, select and update benchmark.
+type QueryStrategy interface {
+	Insert(row benchmarkRow) (string, []any, error)
+	SelectID(name string) (string, []any, error)
+	Update(id int, value1 float64) (string, []any, error)
+	SelectValue(id int) (string, []any, error)
+}
+
+// multiRowInserter is implemented by strategies building a multi-row insert for batch benchmarks.
+type multiRowInserter interface {
+	InsertRows(rows []benchmarkRow) (string, []any, error)
+}
+
+// queryStrategies are compared by benchmarks, a new query builder needs only an adapter here.
+var queryStrategies = []struct {
+	name string
+	new  func() (QueryStrategy, error)
+}{
+	{"raw", func() (QueryStrategy, error) { return rawStrategy{}, nil }},                          // dynamic, unsafe SQL
+	{"squirrel", newSquirrelStrategy},                                                             // typed, safe SQL
+	{"sqlf", func() (QueryStrategy, error) { return sqlfStrategy{}, nil }},                        // semi-dynamic, safe SQL
+	{"template-map", func() (QueryStrategy, error) { return newTemplateStrategy(false) }},         // unsafe SQL
+	{"template-struct", func() (QueryStrategy, error) { return newTemplateStrategy(true) }},       // unsafe SQL
+	{"pongo2", newPongo2Strategy},                                                                 // unsafe SQL
+	{"builq", func() (QueryStrategy, error) { return builqStrategy{}, nil }},                      // dynamic, safe SQL
+	{"sqlgen", func() (QueryStrategy, error) { return sqlgenStrategy{}, nil }},                    // generated, safe SQL
+	{"sqltemplate-map", func() (QueryStrategy, error) { return newSQLTemplateStrategy(false) }},   // safe SQL
+	{"sqltemplate-struct", func() (QueryStrategy, error) { return newSQLTemplateStrategy(true) }}, // safe SQL
+	{"sqltemplate-pongo2", newSQLTemplatePongo2Strategy},                                          // safe SQL
+}
+
+type rawStrategy struct{}
+
+func (rawStrategy) Insert(row benchmarkRow) (string, []any, error) {
+	return "INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		row.values(), nil
+}
+
+func (rawStrategy) InsertRows(rows []benchmarkRow) (string, []any, error) {
+	var sb strings.Builder
+
+	sb.WriteString("INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES")
+
+	args := make([]any, 0, len(rows)*len(benchmarkColumns))
+
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+
+		sb.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		args = append(args, row.values()...)
+	}
+
+	return sb.String(), args, nil
+}
+
+func (rawStrategy) SelectID(name string) (string, []any, error) {
+	return "SELECT id FROM benchmark WHERE name = ?", []any{name}, nil
+}
+
+func (rawStrategy) Update(id int, value1 float64) (string, []any, error) {
+	return "UPDATE benchmark SET value1 = ? WHERE id = ?", []any{value1, id}, nil
+}
+
+func (rawStrategy) SelectValue(id int) (string, []any, error) {
+	return "SELECT value1 FROM benchmark WHERE id = ?", []any{id}, nil
+}
+
+type squirrelStrategy struct {
+	psql sq.StatementBuilderType
+}
+
+func newSquirrelStrategy() (QueryStrategy, error) {
+	return squirrelStrategy{psql: sq.StatementBuilder.PlaceholderFormat(sq.Question)}, nil
+}
+
+func (s squirrelStrategy) Insert(row benchmarkRow) (string, []any, error) {
+	return s.psql.Insert("benchmark").Columns(benchmarkColumns...).Values(row.values()...).ToSql()
+}
+
+func (s squirrelStrategy) InsertRows(rows []benchmarkRow) (string, []any, error) {
+	insert := s.psql.Insert("benchmark").Columns(benchmarkColumns...)
+
+	for _, row := range rows {
+		insert = insert.Values(row.values()...)
+	}
+
+	return insert.ToSql()
+}
+
+func (s squirrelStrategy) SelectID(name string) (string, []any, error) {
+	return s.psql.Select("id").From("benchmark").Where(sq.Eq{"name": name}).ToSql()
+}
+
+func (s squirrelStrategy) Update(id int, value1 float64) (string, []any, error) {
+	return s.psql.Update("benchmark").Set("value1", value1).Where(sq.Eq{"id": id}).ToSql()
+}
+
+func (s squirrelStrategy) SelectValue(id int) (string, []any, error) {
+	return s.psql.Select("value1").From("benchmark").Where(sq.Eq{"id": id}).ToSql()
+}
+
+type sqlfStrategy struct{}
+
+func (sqlfStrategy) build(query *sqlf.Query) (string, []any, error) {
+	return query.Query(sqlf.SQLServerBindVar), query.Args(), nil
+}
+
+func (s sqlfStrategy) Insert(row benchmarkRow) (string, []any, error) {
+	return s.build(sqlf.Sprintf("INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES(%s, %f, %f, %f, %f, %f, %f, %f, %f, %f)",
+		row.values()...))
+}
+
+func (s sqlfStrategy) InsertRows(rows []benchmarkRow) (string, []any, error) {
+	values := make([]*sqlf.Query, len(rows))
+
+	for i, row := range rows {
+		values[i] = sqlf.Sprintf("(%s, %f, %f, %f, %f, %f, %f, %f, %f, %f)", row.values()...)
+	}
+
+	return s.build(sqlf.Sprintf("INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES %s",
+		sqlf.Join(values, ", ")))
+}
+
+func (s sqlfStrategy) SelectID(name string) (string, []any, error) {
+	return s.build(sqlf.Sprintf("SELECT id FROM benchmark WHERE name = %s", name))
+}
+
+func (s sqlfStrategy) Update(id int, value1 float64) (string, []any, error) {
+	return s.build(sqlf.Sprintf("UPDATE benchmark SET value1 = %f WHERE id = %d", value1, id))
+}
+
+func (s sqlfStrategy) SelectValue(id int) (string, []any, error) {
+	return s.build(sqlf.Sprintf("SELECT value1 FROM benchmark WHERE id = %s", id))
+}
+
+// templateStrategy renders values into SQL, data is passed either as maps or as structs.
+type templateStrategy struct {
+	insert, insertRows, selectID, update, selectValue *template.Template
+
+	structs bool
+	buf     bytes.Buffer
+}
+
+func newTemplateStrategy(structs bool) (QueryStrategy, error) {
+	s := &templateStrategy{structs: structs}
+
+	for _, t := range []struct {
+		tmpl **template.Template
+		name string
+		text string
+	}{
+		{&s.insert, "insert", `INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES('{{.Name}}', {{.Value1}}, {{.Value2}}, {{.Value3}}, {{.Value4}}, {{.Value5}}, {{.Value6}}, {{.Value7}}, {{.Value8}}, {{.Value9}})`},
+		{&s.insertRows, "insertRows", `INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES {{range $i, $r := .}}{{if $i}}, {{end}}('{{$r.Name}}', {{$r.Value1}}, {{$r.Value2}}, {{$r.Value3}}, {{$r.Value4}}, {{$r.Value5}}, {{$r.Value6}}, {{$r.Value7}}, {{$r.Value8}}, {{$r.Value9}}){{end}}`},
+		{&s.selectID, "selectId", `SELECT id FROM benchmark WHERE name = '{{.Name}}'`},
+		{&s.update, "update", `UPDATE benchmark SET value1 = {{.Value1}} WHERE id = {{.Id}}`},
+		{&s.selectValue, "selectValue", `SELECT value1 FROM benchmark WHERE id = {{.Id}}`},
+	} {
+		tmpl, err := template.New(t.name).Parse(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s template: %w", t.name, err)
+		}
+
+		*t.tmpl = tmpl
+	}
+
+	return s, nil
+}
+
+func (s *templateStrategy) execute(tmpl *template.Template, data any) (string, []any, error) {
+	s.buf.Reset()
+
+	if err := tmpl.Execute(&s.buf, data); err != nil {
+		return "", nil, err
+	}
+
+	return s.buf.String(), nil, nil
+}
+
+func (s *templateStrategy) Insert(row benchmarkRow) (string, []any, error) {
+	if s.structs {
+		return s.execute(s.insert, row)
+	}
+
+	return s.execute(s.insert, rowMap(row))
+}
+
+func rowMap(row benchmarkRow) map[string]any {
+	return map[string]any{
+		"Name":   row.Name,
+		"Value1": row.Value1,
+		"Value2": row.Value2,
+		"Value3": row.Value3,
+		"Value4": row.Value4,
+		"Value5": row.Value5,
+		"Value6": row.Value6,
+		"Value7": row.Value7,
+		"Value8": row.Value8,
+		"Value9": row.Value9,
+	}
+}
+
+func (s *templateStrategy) InsertRows(rows []benchmarkRow) (string, []any, error) {
+	if s.structs {
+		return s.execute(s.insertRows, rows)
+	}
+
+	maps := make([]map[string]any, len(rows))
+
+	for i, row := range rows {
+		maps[i] = rowMap(row)
+	}
+
+	return s.execute(s.insertRows, maps)
+}
+
+func (s *templateStrategy) SelectID(name string) (string, []any, error) {
+	if s.structs {
+		return s.execute(s.selectID, struct{ Name string }{name})
+	}
+
+	return s.execute(s.selectID, map[string]any{"Name": name})
+}
+
+func (s *templateStrategy) Update(id int, value1 float64) (string, []any, error) {
+	if s.structs {
+		return s.execute(s.update, struct {
+			Value1 float64
+			Id     int
+		}{value1, id})
+	}
+
+	return s.execute(s.update, map[string]any{"Value1": value1, "Id": id})
+}
+
+func (s *templateStrategy) SelectValue(id int) (string, []any, error) {
+	if s.structs {
+		return s.execute(s.selectValue, struct{ Id int }{id})
+	}
+
+	return s.execute(s.selectValue, map[string]any{"Id": id})
+}
+
+type pongo2Strategy struct {
+	insert, insertRows, selectID, update, selectValue *pongo2.Template
+}
+
+func newPongo2Strategy() (QueryStrategy, error) {
+	s := &pongo2Strategy{}
+
+	for _, t := range []struct {
+		tpl  **pongo2.Template
+		name string
+		text string
+	}{
+		{&s.insert, "insert", "INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES('{{ name }}', {{ value1 }}, {{ value2 }}, {{ value3 }}, {{ value4 }}, {{ value5 }}, {{ value6 }}, {{ value7 }}, {{ value8 }}, {{ value9 }})"},
+		{&s.insertRows, "insertRows", "INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES {% for r in rows %}{% if not forloop.First %}, {% endif %}('{{ r.name }}', {{ r.value1 }}, {{ r.value2 }}, {{ r.value3 }}, {{ r.value4 }}, {{ r.value5 }}, {{ r.value6 }}, {{ r.value7 }}, {{ r.value8 }}, {{ r.value9 }}){% endfor %}"},
+		{&s.selectID, "selectId", "SELECT id FROM benchmark WHERE name = '{{ name }}'"},
+		{&s.update, "update", "UPDATE benchmark SET value1 = {{ value1 }} WHERE id = {{ id }}"},
+		{&s.selectValue, "selectValue", "SELECT value1 FROM benchmark WHERE id = {{ id }}"},
+	} {
+		tpl, err := pongo2.FromString(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s template: %w", t.name, err)
+		}
+
+		*t.tpl = tpl
+	}
+
+	return s, nil
+}
+
+func (s *pongo2Strategy) execute(tpl *pongo2.Template, ctx pongo2.Context) (string, []any, error) {
+	query, err := tpl.Execute(ctx)
+
+	return query, nil, err
+}
+
+func (s *pongo2Strategy) Insert(row benchmarkRow) (string, []any, error) {
+	return s.execute(s.insert, rowContext(row))
+}
+
+func rowContext(row benchmarkRow) pongo2.Context {
+	return pongo2.Context{
+		"name":   row.Name,
+		"value1": row.Value1,
+		"value2": row.Value2,
+		"value3": row.Value3,
+		"value4": row.Value4,
+		"value5": row.Value5,
+		"value6": row.Value6,
+		"value7": row.Value7,
+		"value8": row.Value8,
+		"value9": row.Value9,
+	}
+}
+
+func (s *pongo2Strategy) InsertRows(rows []benchmarkRow) (string, []any, error) {
+	contexts := make([]pongo2.Context, len(rows))
+
+	for i, row := range rows {
+		contexts[i] = rowContext(row)
+	}
+
+	return s.execute(s.insertRows, pongo2.Context{"rows": contexts})
+}
+
+func (s *pongo2Strategy) SelectID(name string) (string, []any, error) {
+	return s.execute(s.selectID, pongo2.Context{"name": name})
+}
+
+func (s *pongo2Strategy) Update(id int, value1 float64) (string, []any, error) {
+	return s.execute(s.update, pongo2.Context{"value1": value1, "id": id})
+}
+
+func (s *pongo2Strategy) SelectValue(id int) (string, []any, error) {
+	return s.execute(s.selectValue, pongo2.Context{"id": id})
+}
+
+type builqStrategy struct{}
+
+func (builqStrategy) Insert(row benchmarkRow) (string, []any, error) {
+	bb := builq.Builder{}
+
+	bb.Addf("INSERT INTO benchmark (%s)", builq.Columns(benchmarkColumns))
+	bb.Addf("VALUES (%+$)", row.values())
+
+	return bb.Build()
+}
+
+func (builqStrategy) InsertRows(rows []benchmarkRow) (string, []any, error) {
+	values := make([][]any, len(rows))
+
+	for i, row := range rows {
+		values[i] = row.values()
+	}
+
+	bb := builq.Builder{}
+
+	bb.Addf("INSERT INTO benchmark (%s)", builq.Columns(benchmarkColumns))
+	bb.Addf("VALUES %#$", values)
+
+	return bb.Build()
+}
+
+func (builqStrategy) SelectID(name string) (string, []any, error) {
+	bf := builq.New()
+	bf("SELECT %s FROM %s", "id", "benchmark")
+	bf("WHERE %s = %$", "name", name)
+
+	return bf.Build()
+}
+
+func (builqStrategy) Update(id int, value1 float64) (string, []any, error) {
+	bb := builq.Builder{}
+	bb.Addf("UPDATE benchmark SET value1 = %$ WHERE id = %$", value1, id)
+
+	return bb.Build()
+}
+
+func (builqStrategy) SelectValue(id int) (string, []any, error) {
+	bf := builq.New()
+	bf("SELECT %s FROM %s", "value1", "benchmark")
+	bf("WHERE %s = %$", "id", id)
+
+	return bf.Build()
+}
+
+// sqlgenStrategy uses SQL of functions generated by cmd/sqlgen from db/queries/queries.sql, their typed methods
+// are benchmarked by BenchmarkSQLiteQueries. The SQL is constant, so it is not a multiRowInserter.
+type sqlgenStrategy struct{}
+
+func (sqlgenStrategy) Insert(row benchmarkRow) (string, []any, error) {
+	query, args := queries.InsertQuery(row.Name, row.Value1, row.Value2, row.Value3, row.Value4, row.Value5, row.Value6, row.Value7, row.Value8, row.Value9)
+
+	return query, args, nil
+}
+
+func (sqlgenStrategy) SelectID(name string) (string, []any, error) {
+	query, args := queries.SelectIDQuery(name)
+
+	return query, args, nil
+}
+
+func (sqlgenStrategy) Update(id int, value1 float64) (string, []any, error) {
+	query, args := queries.UpdateQuery(value1, int64(id))
+
+	return query, args, nil
+}
+
+func (sqlgenStrategy) SelectValue(id int) (string, []any, error) {
+	query, args := queries.SelectValueQuery(int64(id))
+
+	return query, args, nil
+}
+
+// sqltemplateStrategy renders the templates of templateStrategy with placeholders of db/sqltemplate.
+type sqltemplateStrategy struct {
+	insert, insertRows, selectID, update, selectValue *sqltemplate.Template
+
+	structs bool
+}
+
+func newSQLTemplateStrategy(structs bool) (QueryStrategy, error) {
+	s := &sqltemplateStrategy{structs: structs}
+
+	for _, t := range []struct {
+		tmpl **sqltemplate.Template
+		name string
+		text string
+	}{
+		{&s.insert, "insert", `INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES({{.Name}}, {{.Value1}}, {{.Value2}}, {{.Value3}}, {{.Value4}}, {{.Value5}}, {{.Value6}}, {{.Value7}}, {{.Value8}}, {{.Value9}})`},
+		{&s.insertRows, "insertRows", `INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES {{range $i, $r := .}}{{if $i}}, {{end}}({{$r.Name}}, {{$r.Value1}}, {{$r.Value2}}, {{$r.Value3}}, {{$r.Value4}}, {{$r.Value5}}, {{$r.Value6}}, {{$r.Value7}}, {{$r.Value8}}, {{$r.Value9}}){{end}}`},
+		{&s.selectID, "selectId", `SELECT id FROM benchmark WHERE name = {{.Name}}`},
+		{&s.update, "update", `UPDATE benchmark SET value1 = {{.Value1}} WHERE id = {{.Id}}`},
+		{&s.selectValue, "selectValue", `SELECT value1 FROM benchmark WHERE id = {{.Id}}`},
+	} {
+		tmpl, err := sqltemplate.New(t.name, t.text)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s template: %w", t.name, err)
+		}
+
+		*t.tmpl = tmpl
+	}
+
+	return s, nil
+}
+
+func (s *sqltemplateStrategy) Insert(row benchmarkRow) (string, []any, error) {
+	if s.structs {
+		return s.insert.Execute(row)
+	}
+
+	return s.insert.Execute(rowMap(row))
+}
+
+func (s *sqltemplateStrategy) InsertRows(rows []benchmarkRow) (string, []any, error) {
+	if s.structs {
+		return s.insertRows.Execute(rows)
+	}
+
+	maps := make([]map[string]any, len(rows))
+
+	for i, row := range rows {
+		maps[i] = rowMap(row)
+	}
+
+	return s.insertRows.Execute(maps)
+}
+
+func (s *sqltemplateStrategy) SelectID(name string) (string, []any, error) {
+	if s.structs {
+		return s.selectID.Execute(struct{ Name string }{name})
+	}
+
+	return s.selectID.Execute(map[string]any{"Name": name})
+}
+
+func (s *sqltemplateStrategy) Update(id int, value1 float64) (string, []any, error) {
+	if s.structs {
+		return s.update.Execute(struct {
+			Value1 float64
+			Id     int
+		}{value1, id})
+	}
+
+	return s.update.Execute(map[string]any{"Value1": value1, "Id": id})
+}
+
+func (s *sqltemplateStrategy) SelectValue(id int) (string, []any, error) {
+	if s.structs {
+		return s.selectValue.Execute(struct{ Id int }{id})
+	}
+
+	return s.selectValue.Execute(map[string]any{"Id": id})
+}
+
+// sqltemplatePongo2Strategy renders the templates of pongo2Strategy with sqlbind tags of db/sqltemplate.
+type sqltemplatePongo2Strategy struct {
+	insert, insertRows, selectID, update, selectValue *sqltemplate.Pongo2Template
+}
+
+func newSQLTemplatePongo2Strategy() (QueryStrategy, error) {
+	s := &sqltemplatePongo2Strategy{}
+
+	for _, t := range []struct {
+		tpl  **sqltemplate.Pongo2Template
+		name string
+		text string
+	}{
+		{&s.insert, "insert", "INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES({% sqlbind name %}, {% sqlbind value1 %}, {% sqlbind value2 %}, {% sqlbind value3 %}, {% sqlbind value4 %}, {% sqlbind value5 %}, {% sqlbind value6 %}, {% sqlbind value7 %}, {% sqlbind value8 %}, {% sqlbind value9 %})"},
+		{&s.insertRows, "insertRows", "INSERT INTO benchmark(name, value1, value2, value3, value4, value5, value6, value7, value8, value9) VALUES {% for r in rows %}{% if not forloop.First %}, {% endif %}({% sqlbind r.name %}, {% sqlbind r.value1 %}, {% sqlbind r.value2 %}, {% sqlbind r.value3 %}, {% sqlbind r.value4 %}, {% sqlbind r.value5 %}, {% sqlbind r.value6 %}, {% sqlbind r.value7 %}, {% sqlbind r.value8 %}, {% sqlbind r.value9 %}){% endfor %}"},
+		{&s.selectID, "selectId", "SELECT id FROM benchmark WHERE name = {% sqlbind name %}"},
+		{&s.update, "update", "UPDATE benchmark SET value1 = {% sqlbind value1 %} WHERE id = {% sqlbind id %}"},
+		{&s.selectValue, "selectValue", "SELECT value1 FROM benchmark WHERE id = {% sqlbind id %}"},
+	} {
+		tpl, err := sqltemplate.NewPongo2(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s template: %w", t.name, err)
+		}
+
+		*t.tpl = tpl
+	}
+
+	return s, nil
+}
+
+func (s *sqltemplatePongo2Strategy) Insert(row benchmarkRow) (string, []any, error) {
+	return s.insert.Execute(rowContext(row))
+}
+
+func (s *sqltemplatePongo2Strategy) InsertRows(rows []benchmarkRow) (string, []any, error) {
+	contexts := make([]pongo2.Context, len(rows))
+
+	for i, row := range rows {
+		contexts[i] = rowContext(row)
+	}
+
+	return s.insertRows.Execute(pongo2.Context{"rows": contexts})
+}
+
+func (s *sqltemplatePongo2Strategy) SelectID(name string) (string, []any, error) {
+	return s.selectID.Execute(pongo2.Context{"name": name})
+}
+
+func (s *sqltemplatePongo2Strategy) Update(id int, value1 float64) (string, []any, error) {
+	return s.update.Execute(pongo2.Context{"value1": value1, "id": id})
+}
+
+func (s *sqltemplatePongo2Strategy) SelectValue(id int) (string, []any, error) {
+	return s.selectValue.Execute(pongo2.Context{"id": id})
+}
@@ -1,6 +1,6 @@
 module code.local/go-benchmarks
 
-go 1.23
+go 1.23.0
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.5.2
@@ -12,6 +12,7 @@ require (
 	github.com/cespare/xxhash/v2 v2.2.0
 	github.com/cristalhq/builq v0.15.0
 	github.com/dghubble/trie v0.1.0
+	github.com/dgryski/go-metro v0.0.0-20200812162917-85c65e2d0165
 	github.com/dolthub/swiss v0.2.1
 	github.com/falmar/goradix v0.0.0-20230113174055-90e47463f13b
 	github.com/flosch/pongo2/v6 v6.0.0
@@ -21,13 +22,15 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.24
 	github.com/panmari/cuckoofilter v1.0.6
 	github.com/snorwin/gorax v1.0.1
+	golang.org/x/tools v0.36.0
 )
 
 require (
-	github.com/dgryski/go-metro v0.0.0-20200812162917-85c65e2d0165 // indirect
 	github.com/dolthub/maphash v0.1.0 // indirect
 	github.com/emicklei/dot v0.16.0 // indirect
 	github.com/lann/builder v0.0.0-20180802200727-47ae307949d0 // indirect
 	github.com/lann/ps v0.0.0-20150810152359-62de8c46ede0 // indirect
 	golang.org/x/exp v0.0.0-20240314144324-c7f7c6466f7f // indirect
+	golang.org/x/mod v0.27.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
 )
@@ -0,0 +1,211 @@
+package histogram
+
+import (
+	"encoding/json"
+	"math"
+	"math/bits"
+	"time"
+)
+
+/*
+	Histogram is an HDR-style log-linear histogram of uint64 values
+		every power of two range [2^e, 2^(e+1)) is split into subBucketsCount linear sub-buckets,
+		values below subBucketsCount are counted exactly,
+		so relative error of any reported value is below 1/subBucketsCount
+*/
+
+const (
+	subBucketsBits  = 5
+	subBucketsCount = 1 << subBucketsBits
+
+	bucketsCount = (64 - subBucketsBits + 1) * subBucketsCount
+)
+
+type Histogram struct {
+	counts [bucketsCount]uint64
+
+	count uint64
+	sum   float64
+	min   uint64
+	max   uint64
+}
+
+func New() *Histogram {
+	return &Histogram{min: math.MaxUint64}
+}
+
+func bucketIndex(v uint64) int {
+	if v < subBucketsCount {
+		return int(v)
+	}
+
+	// Number of low bits dropped from the value, leading one bit is implied by the bucket.
+	shift := bits.Len64(v) - 1 - subBucketsBits
+
+	return (shift+1)*subBucketsCount + int((v>>shift)&(subBucketsCount-1))
+}
+
+func bucketLowerBound(i int) uint64 {
+	if i < subBucketsCount {
+		return uint64(i)
+	}
+
+	shift := i/subBucketsCount - 1
+
+	return (subBucketsCount + uint64(i%subBucketsCount)) << shift
+}
+
+func bucketUpperBound(i int) uint64 {
+	if i == bucketsCount-1 {
+		return math.MaxUint64
+	}
+
+	return bucketLowerBound(i+1) - 1
+}
+
+func (h *Histogram) Record(v uint64) {
+	h.counts[bucketIndex(v)]++
+
+	h.count++
+	h.sum += float64(v)
+
+	if v < h.min {
+		h.min = v
+	}
+
+	if v > h.max {
+		h.max = v
+	}
+}
+
+func (h *Histogram) RecordDuration(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+
+	h.Record(uint64(d))
+}
+
+// Merge adds all values recorded by other into h.
+func (h *Histogram) Merge(other *Histogram) {
+	if other.count == 0 {
+		return
+	}
+
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+
+	h.count += other.count
+	h.sum += other.sum
+
+	if other.min < h.min {
+		h.min = other.min
+	}
+
+	if other.max > h.max {
+		h.max = other.max
+	}
+}
+
+func (h *Histogram) Reset() {
+	*h = Histogram{min: math.MaxUint64}
+}
+
+func (h *Histogram) Count() uint64 {
+	return h.count
+}
+
+func (h *Histogram) Min() uint64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	return h.min
+}
+
+func (h *Histogram) Max() uint64 {
+	return h.max
+}
+
+func (h *Histogram) Mean() float64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	return h.sum / float64(h.count)
+}
+
+// Quantile returns the highest value that is equivalent, within histogram precision,
+// to the value at quantile q, where q is in range [0, 1].
+func (h *Histogram) Quantile(q float64) uint64 {
+	if h.count == 0 {
+		return 0
+	}
+
+	rank := uint64(math.Ceil(q * float64(h.count)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var seen uint64
+
+	for i, c := range h.counts {
+		seen += c
+
+		if seen >= rank {
+			return min(bucketUpperBound(i), h.max)
+		}
+	}
+
+	return h.max
+}
+
+type Bucket struct {
+	LowerBound uint64 `json:"lowerBound"`
+	UpperBound uint64 `json:"upperBound"`
+	Count      uint64 `json:"count"`
+}
+
+// Buckets returns all non-empty buckets in ascending order.
+func (h *Histogram) Buckets() []Bucket {
+	var buckets []Bucket
+
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+
+		buckets = append(buckets, Bucket{
+			LowerBound: bucketLowerBound(i),
+			UpperBound: bucketUpperBound(i),
+			Count:      c,
+		})
+	}
+
+	return buckets
+}
+
+func (h *Histogram) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Count   uint64   `json:"count"`
+		Min     uint64   `json:"min"`
+		Max     uint64   `json:"max"`
+		Mean    float64  `json:"mean"`
+		P50     uint64   `json:"p50"`
+		P90     uint64   `json:"p90"`
+		P99     uint64   `json:"p99"`
+		P999    uint64   `json:"p999"`
+		Buckets []Bucket `json:"buckets"`
+	}{
+		Count:   h.Count(),
+		Min:     h.Min(),
+		Max:     h.Max(),
+		Mean:    h.Mean(),
+		P50:     h.Quantile(0.5),
+		P90:     h.Quantile(0.9),
+		P99:     h.Quantile(0.99),
+		P999:    h.Quantile(0.999),
+		Buckets: h.Buckets(),
+	})
+}
@@ -0,0 +1,133 @@
+package histogram
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+func TestBucketBounds(t *testing.T) {
+	const iterations = 1000
+
+	g := randomtest.New(t)
+
+	values := []uint64{0, 1, subBucketsCount - 1, subBucketsCount, subBucketsCount + 1, math.MaxUint64}
+
+	for i := 0; i < iterations; i++ {
+		values = append(values, g.Uint64()>>g.IntN(64))
+	}
+
+	for _, v := range values {
+		i := bucketIndex(v)
+
+		if i < 0 || i >= bucketsCount {
+			t.Fatalf("Bucket index %d out of range for value %d", i, v)
+		}
+
+		if lower, upper := bucketLowerBound(i), bucketUpperBound(i); v < lower || v > upper {
+			t.Errorf("Value %d outside of its bucket [%d, %d]", v, lower, upper)
+		}
+	}
+
+	for i := 1; i < bucketsCount; i++ {
+		if bucketLowerBound(i) != bucketUpperBound(i-1)+1 {
+			t.Fatalf("Gap between buckets %d and %d", i-1, i)
+		}
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	const iterations = 100000
+
+	g := randomtest.New(t)
+	h := New()
+
+	values := make([]uint64, iterations)
+	for i := range values {
+		values[i] = uint64(g.ExpFloat64() * 10000)
+
+		h.Record(values[i])
+	}
+
+	slices.Sort(values)
+
+	for _, q := range []float64{0, 0.5, 0.9, 0.99, 0.999, 1} {
+		rank := int(math.Ceil(q*iterations)) - 1
+		if rank < 0 {
+			rank = 0
+		}
+
+		expected := values[rank]
+		got := h.Quantile(q)
+
+		if got < expected || float64(got-expected) > float64(expected)/subBucketsCount {
+			t.Errorf("Quantile %v mismatch: expected %d, got %d", q, expected, got)
+		}
+	}
+
+	if h.Min() != values[0] || h.Max() != values[iterations-1] {
+		t.Errorf("Min/Max mismatch: expected %d/%d, got %d/%d", values[0], values[iterations-1], h.Min(), h.Max())
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a, b, all := New(), New(), New()
+
+	for i := uint64(0); i < 1000; i++ {
+		if i%2 == 0 {
+			a.Record(i * i)
+		} else {
+			b.Record(i * i)
+		}
+
+		all.Record(i * i)
+	}
+
+	a.Merge(b)
+
+	if a.Count() != all.Count() || a.Min() != all.Min() || a.Max() != all.Max() || a.Mean() != all.Mean() {
+		t.Fatal("Merged histogram summary differs")
+	}
+
+	if !slices.Equal(a.Buckets(), all.Buckets()) {
+		t.Fatal("Merged histogram buckets differ")
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	h := New()
+
+	for i := uint64(1); i <= 100; i++ {
+		h.Record(i)
+	}
+
+	data, err := json.Marshal(h)
+	if err != nil {
+		t.Fatalf("Marshal returned an error: %v", err)
+	}
+
+	var decoded struct {
+		Count   uint64   `json:"count"`
+		P50     uint64   `json:"p50"`
+		Buckets []Bucket `json:"buckets"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal returned an error: %v", err)
+	}
+
+	if decoded.Count != 100 || decoded.P50 != h.Quantile(0.5) || len(decoded.Buckets) != len(h.Buckets()) {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+}
+
+func BenchmarkRecord(b *testing.B) {
+	h := New()
+
+	for i := 0; i < b.N; i++ {
+		h.Record(uint64(i))
+	}
+}
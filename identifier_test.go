@@ -0,0 +1,52 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+func BenchmarkSetsIdentifiers(b *testing.B) {
+	const n = 4096
+
+	g := randomtest.New(b)
+
+	// Clock starts at a fixed time, so time-ordered identifiers are reproducible from the seed.
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	generate := func(id func() string) []string {
+		keys := make([]string, n)
+
+		for i := range keys {
+			keys[i] = id()
+		}
+
+		return keys
+	}
+
+	// Time-ordered identifiers are inserted in creation order, as objects get them, random ones in any order.
+	workloads := []struct {
+		name string
+		keys []string
+	}{
+		{"uuid-v4", generate(g.UUIDv4)},
+		{"uuid-v7", g.TimeOrdered(n, start, time.Millisecond, g.UUIDv7)},
+		{"ulid", g.TimeOrdered(n, start, time.Millisecond, g.ULID)},
+		{"base32", generate(func() string { return g.Base32ID(26) })},
+		{"base58", generate(func() string { return g.Base58ID(22) })},
+		{"fqdn", generate(func() string { return g.FQDN(g.IntN(4) + 1) })},
+		{"file-path", generate(func() string { return g.FilePath(random.FilePathDepths) })},
+	}
+
+	for _, w := range workloads {
+		b.Run(w.name, func(b *testing.B) {
+			for _, f := range setFactories {
+				b.Run(f.name, func(b *testing.B) {
+					benchmarkSetKeys(b, f.new(len(w.keys)), w.keys, nil)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"code.local/go-benchmarks/histogram"
+)
+
+// go test -bench=BenchmarkSetsYCSB -latency -latency.json=/tmp/latency .
+
+var (
+	latencyFlag     = flag.Bool("latency", false, "time individual set operations and report p50/p90/p99/p999 latencies")
+	latencyJSONFlag = flag.String("latency.json", "", "directory to dump full latency histograms of benchmarks as JSON files")
+)
+
+// reportLatency reports percentiles of the operation latencies in nanoseconds,
+// and dumps the histogram into the latency.json directory when requested.
+func reportLatency(b *testing.B, h *histogram.Histogram) {
+	b.Helper()
+
+	if h.Count() == 0 {
+		return
+	}
+
+	b.ReportMetric(float64(h.Quantile(0.5)), "p50-ns")
+	b.ReportMetric(float64(h.Quantile(0.9)), "p90-ns")
+	b.ReportMetric(float64(h.Quantile(0.99)), "p99-ns")
+	b.ReportMetric(float64(h.Quantile(0.999)), "p999-ns")
+
+	if *latencyJSONFlag == "" {
+		return
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		b.Fatalf("could not marshal latency histogram: %v", err)
+	}
+
+	if err := os.MkdirAll(*latencyJSONFlag, 0o755); err != nil {
+		b.Fatalf("could not create latency histograms directory: %v", err)
+	}
+
+	name := strings.NewReplacer("/", "_", " ", "_", "%", "pct").Replace(b.Name()) + ".json"
+
+	if err := os.WriteFile(filepath.Join(*latencyJSONFlag, name), data, 0o644); err != nil {
+		b.Fatalf("could not write latency histogram: %v", err)
+	}
+}
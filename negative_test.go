@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+func BenchmarkSetsNegativeLookup(b *testing.B) {
+	const size = 1 << 12
+
+	g := randomtest.New(b)
+
+	keys, err := g.UniqueStrings(size, random.KubernetesNameLengths, random.KubernetesNamesAllowedChars)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// Absent keys have the same length distribution, so lookups can not be rejected by length alone.
+	absent, err := g.Disjoint(size, keys, random.KubernetesNameLengths, random.KubernetesNamesAllowedChars)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, f := range setFactories {
+		b.Run(f.name, func(b *testing.B) {
+			s := f.new(size)
+
+			for _, key := range keys {
+				s.Insert(key)
+			}
+
+			// Probabilistic filters may report absent keys as present.
+			falsePositives := 0
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if s.Contains(absent[i%size]) {
+					falsePositives++
+				}
+			}
+
+			b.ReportMetric(float64(falsePositives)/float64(b.N), "false-positives/op")
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+// namespacedKeys returns realistic `namespace/name` keys, names follow generated Pod names
+// of a Deployment, e.g. `tenant-7/api-5d8f9c7b4x-k2xqz`. Keys of namespaces[i] are keys[i*namesPerNamespace:(i+1)*namesPerNamespace].
+func namespacedKeys(g *random.Generator, namespacesCount, namesPerNamespace int) (namespaces, keys []string) {
+	workloads := []string{"api", "web", "worker", "redis", "postgres", "ingress-nginx", "coredns", "prometheus"}
+
+	suffix := func(n int) string {
+		return g.String(n, random.GeneratedNameChars)
+	}
+
+	namespaces = make([]string, namespacesCount)
+	keys = make([]string, 0, namespacesCount*namesPerNamespace)
+
+	for i := range namespaces {
+		namespaces[i] = fmt.Sprintf("tenant-%d", i)
+
+		seen := make(map[string]struct{}, namesPerNamespace)
+
+		for len(seen) < namesPerNamespace {
+			key := namespaces[i] + "/" + workloads[g.IntN(len(workloads))] + "-" + suffix(10) + "-" + suffix(5)
+
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+
+	return namespaces, keys
+}
+
+func BenchmarkSetsOrdered(b *testing.B) {
+	const (
+		namespacesCount   = 64
+		namesPerNamespace = 64
+	)
+
+	namespaces, keys := namespacedKeys(randomtest.New(b), namespacesCount, namesPerNamespace)
+
+	newLoadedSet := func(f setFactory) set {
+		s := f.new(len(keys))
+
+		for _, key := range keys {
+			s.Insert(key)
+		}
+
+		return s
+	}
+
+	b.Run("list-namespace", func(b *testing.B) {
+		for _, f := range setFactories {
+			b.Run(f.name, func(b *testing.B) {
+				walker, ok := newLoadedSet(f).(prefixWalker)
+				if !ok {
+					b.Skip("prefix iteration is not supported")
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					count := 0
+
+					walker.WalkPrefix(namespaces[i%namespacesCount]+"/", func(string) bool {
+						count++
+
+						return true
+					})
+
+					if count != namesPerNamespace {
+						b.Fatalf("data mismatch: expected %d, got %d.", namesPerNamespace, count)
+					}
+				}
+			})
+		}
+	})
+
+	b.Run("longest-prefix", func(b *testing.B) {
+		for _, f := range setFactories {
+			b.Run(f.name, func(b *testing.B) {
+				matcher, ok := newLoadedSet(f).(longestPrefixMatcher)
+				if !ok {
+					b.Skip("longest prefix match is not supported")
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					key := keys[i%len(keys)]
+
+					// Subresource path of an object resolves to the object itself.
+					longest, ok := matcher.LongestPrefix(key + "/status")
+					if !ok || longest != key {
+						b.Fatalf("data mismatch: expected %s, got %s.", key, longest)
+					}
+				}
+			})
+		}
+	})
+
+	b.Run("range-scan", func(b *testing.B) {
+		const count = 10
+
+		for _, f := range setFactories {
+			b.Run(f.name, func(b *testing.B) {
+				ordered, ok := newLoadedSet(f).(orderedSet)
+				if !ok {
+					b.Skip("unordered structure, scans are not supported")
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					visited := 0
+
+					ordered.Scan(namespaces[i%namespacesCount]+"/", count, func(string) bool {
+						visited++
+
+						return true
+					})
+
+					if visited != count {
+						b.Fatalf("data mismatch: expected %d, got %d.", count, visited)
+					}
+				}
+			})
+		}
+	})
+
+	b.Run("min-max", func(b *testing.B) {
+		for _, f := range setFactories {
+			b.Run(f.name, func(b *testing.B) {
+				ordered, ok := newLoadedSet(f).(orderedSet)
+				if !ok {
+					b.Skip("unordered structure, min and max are not supported")
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					if _, ok := ordered.Min(); !ok {
+						b.FailNow()
+					}
+
+					if _, ok := ordered.Max(); !ok {
+						b.FailNow()
+					}
+				}
+			})
+		}
+	})
+
+	b.Run("delete-namespace", func(b *testing.B) {
+		for _, f := range setFactories {
+			b.Run(f.name, func(b *testing.B) {
+				s := newLoadedSet(f)
+
+				walker, ok := s.(prefixWalker)
+				if !ok {
+					b.Skip("prefix iteration is not supported")
+				}
+
+				// Sets without native prefix removal collect matching keys and remove them one by one.
+				deletePrefix := func(prefix string) {
+					var matched []string
+
+					walker.WalkPrefix(prefix, func(key string) bool {
+						matched = append(matched, key)
+
+						return true
+					})
+
+					for _, key := range matched {
+						s.Remove(key)
+					}
+				}
+
+				if deleter, ok := s.(prefixDeleter); ok {
+					deletePrefix = deleter.DeletePrefix
+				}
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					n := i % namespacesCount
+
+					deletePrefix(namespaces[n] + "/")
+
+					b.StopTimer()
+					for _, key := range keys[n*namesPerNamespace : (n+1)*namesPerNamespace] {
+						if s.Contains(key) {
+							b.Fatalf("Contains %q after deleting namespace", key)
+						}
+
+						s.Insert(key)
+					}
+					b.StartTimer()
+				}
+			})
+		}
+	})
+}
+
+func TestOrderedSets(t *testing.T) {
+	const namesPerNamespace = 16
+
+	namespaces, keys := namespacedKeys(randomtest.New(t), 8, namesPerNamespace)
+
+	// Add keys that are prefixes of other keys.
+	keys = append(keys, namespaces[1], namespaces[1]+"/")
+
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+
+	for _, f := range setFactories {
+		t.Run(f.name, func(t *testing.T) {
+			s := f.new(len(keys))
+
+			for _, key := range keys {
+				s.Insert(key)
+			}
+
+			if walker, ok := s.(prefixWalker); ok {
+				var got []string
+
+				walker.WalkPrefix(namespaces[1]+"/", func(key string) bool {
+					got = append(got, key)
+
+					return true
+				})
+
+				slices.Sort(got)
+
+				if expected := sorted[slices.Index(sorted, namespaces[1]+"/"):][:namesPerNamespace+1]; !slices.Equal(got, expected) {
+					t.Errorf("WalkPrefix mismatch: expected %v, got %v", expected, got)
+				}
+			}
+
+			if matcher, ok := s.(longestPrefixMatcher); ok {
+				if got, ok := matcher.LongestPrefix(keys[0] + "/status"); !ok || got != keys[0] {
+					t.Errorf("LongestPrefix mismatch: expected %s, got %s", keys[0], got)
+				}
+
+				if got, ok := matcher.LongestPrefix(namespaces[1] + "-other"); !ok || got != namespaces[1] {
+					t.Errorf("LongestPrefix mismatch: expected %s, got %s", namespaces[1], got)
+				}
+
+				if got, ok := matcher.LongestPrefix("unknown"); ok {
+					t.Errorf("LongestPrefix mismatch: expected no match, got %s", got)
+				}
+			}
+
+			if ordered, ok := s.(orderedSet); ok {
+				if got, _ := ordered.Min(); got != sorted[0] {
+					t.Errorf("Min mismatch: expected %s, got %s", sorted[0], got)
+				}
+
+				if got, _ := ordered.Max(); got != sorted[len(sorted)-1] {
+					t.Errorf("Max mismatch: expected %s, got %s", sorted[len(sorted)-1], got)
+				}
+
+				var got []string
+
+				ordered.Scan(namespaces[1]+"/", 3, func(key string) bool {
+					got = append(got, key)
+
+					return true
+				})
+
+				if expected := sorted[slices.Index(sorted, namespaces[1]+"/"):][:3]; !slices.Equal(got, expected) {
+					t.Errorf("Scan mismatch: expected %v, got %v", expected, got)
+				}
+			}
+
+			if deleter, ok := s.(prefixDeleter); ok {
+				deleter.DeletePrefix(namespaces[2] + "/")
+
+				for _, key := range keys {
+					if strings.HasPrefix(key, namespaces[2]+"/") == s.Contains(key) {
+						t.Fatalf("DeletePrefix mismatch for %q", key)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+
+	xxhash "github.com/cespare/xxhash/v2"
+	metro "github.com/dgryski/go-metro"
+)
+
+/*
+	Adversarial generators produce keys that deliberately hit worst cases of structures,
+	as a tenant choosing names of its objects could.
+		HashPrefixCollisions, HashRowSaturation - rows of charhashmatrix and charbyteshashmatrix (hex digits of xxhash)
+		PrefixChain, LongCommonPrefix - depth and prefix comparisons of tries and radix trees
+		NodeChurn - growing and shrinking of adaptive radix tree nodes
+		CuckooBucketCollisions - relocations of panmari/cuckoofilter
+*/
+
+// ASCIIPrintableChars are all printable ASCII characters, every one is a single byte, as needed by NodeChurn.
+var ASCIIPrintableChars = func() []rune {
+	chars := make([]rune, 0, '~'-' '+1)
+
+	for c := ' '; c <= '~'; c++ {
+		chars = append(chars, c)
+	}
+
+	return chars
+}()
+
+// hashHexLength is the number of hex digits of a 64-bit hash without leading zeros.
+const hashHexLength = 16
+
+// maxRejections bounds consecutive rejected candidates of a key accepted once in 2^bits candidates on average,
+// before lengths and chars are considered unable to produce more keys.
+func maxRejections(bits int) int {
+	if bits >= 62-10 {
+		return math.MaxInt
+	}
+
+	return maxDuplicates << bits
+}
+
+func hashHex(key string) string {
+	return strconv.FormatUint(xxhash.Sum64String(key), 16)
+}
+
+// HashPrefixCollisions returns n distinct keys whose xxhash hex digits share the first prefixLength digits,
+// so they all set the same cells of first rows of a hash matrix. Cost grows with 16^prefixLength per key.
+// It fails when lengths and chars can not produce n such keys.
+func (g *Generator) HashPrefixCollisions(n, prefixLength int, lengths LengthDist, chars []rune) ([]string, error) {
+	prefixLength = min(prefixLength, hashHexLength)
+
+	target := ""
+	keys := make([]string, 0, n)
+	seen := make(map[string]struct{}, n)
+	limit := maxRejections(4 * prefixLength)
+
+	for rejected := 0; len(keys) < n; {
+		if rejected == limit {
+			return nil, fmt.Errorf("%w: %d consecutive rejected candidates after %d of %d keys", ErrKeySpaceExhausted, rejected, len(keys), n)
+		}
+		rejected++
+
+		key := g.StringOf(lengths, chars)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		digits := hashHex(key)
+		if len(digits) < prefixLength {
+			continue
+		}
+
+		if target == "" {
+			target = digits[:prefixLength]
+		} else if digits[:prefixLength] != target {
+			continue
+		}
+
+		rejected = 0
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// HashRowSaturation returns distinct keys whose xxhash hex digits cover every digit at every position,
+// a hash matrix that is set with all of them contains every key with a 16 digit hash.
+// It fails when lengths and chars can not produce keys covering all of them.
+func (g *Generator) HashRowSaturation(lengths LengthDist, chars []rune) ([]string, error) {
+	var (
+		covered [hashHexLength][16]bool
+		missing = hashHexLength*16 - 1
+		keys    []string
+		seen    = make(map[string]struct{})
+	)
+
+	// Hex representation has no leading zeros.
+	covered[0][0] = true
+
+	// The last missing digit is covered by one of 16 keys on average.
+	limit := maxRejections(4)
+
+	for rejected := 0; missing > 0; {
+		if rejected == limit {
+			return nil, fmt.Errorf("%w: %d consecutive rejected candidates with %d digits missing", ErrKeySpaceExhausted, rejected, missing)
+		}
+		rejected++
+
+		key := g.StringOf(lengths, chars)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		h := xxhash.Sum64String(key)
+		if h>>((hashHexLength-1)*4) == 0 {
+			continue // shorter hex representation is shifted to other positions
+		}
+
+		added := false
+
+		for y := 0; y < hashHexLength; y++ {
+			digit := h >> ((hashHexLength - 1 - y) * 4) & 0xf
+
+			if !covered[y][digit] {
+				covered[y][digit] = true
+				missing--
+				added = true
+			}
+		}
+
+		if added {
+			rejected = 0
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+// PrefixChain returns n keys where every key is a prefix of the next one, that is longer by step characters,
+// so a trie has a value at every level of a single deep path.
+func (g *Generator) PrefixChain(n, step int, chars []rune) []string {
+	chain := g.String(n*step, chars)
+
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = chain[:(i+1)*step]
+	}
+
+	return keys
+}
+
+// LongCommonPrefix returns n distinct keys that share the first prefixLength characters and differ
+// in the last suffixLength characters, so every comparison walks the whole shared prefix.
+func (g *Generator) LongCommonPrefix(n, prefixLength, suffixLength int, chars []rune) ([]string, error) {
+	prefix := g.String(prefixLength, chars)
+
+	suffixes, err := g.UniqueStrings(n, Uniform{Min: suffixLength, Max: suffixLength}, chars)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range suffixes {
+		suffixes[i] = prefix + suffixes[i]
+	}
+
+	return suffixes, nil
+}
+
+// NodeChurn returns groups of fanout keys, keys of a group share a distinct prefix of prefixLength characters and
+// differ in the next one. Inserting and removing a group with fanout just above ART node capacities (4, 16 or 48)
+// grows and shrinks the node type every time. Chars need at least fanout distinct single-byte runes.
+func (g *Generator) NodeChurn(groups, fanout, prefixLength int, chars []rune) ([]string, error) {
+	if fanout > len(chars) {
+		return nil, fmt.Errorf("fanout %d exceeds %d available characters", fanout, len(chars))
+	}
+
+	prefixes, err := g.UniqueStrings(groups, Uniform{Min: prefixLength, Max: prefixLength}, chars)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, groups*fanout)
+
+	for _, prefix := range prefixes {
+		for _, i := range g.Perm(len(chars))[:fanout] {
+			keys = append(keys, prefix+string(chars[i]))
+		}
+	}
+
+	return keys, nil
+}
+
+// Hashing of panmari/cuckoofilter, see getIndexAndFingerprint and getAltIndex.
+const (
+	cuckooHashSeed            = 1337
+	cuckooFingerprintSizeBits = 16
+	cuckooMaxFingerprint      = 1<<cuckooFingerprintSizeBits - 1
+)
+
+func cuckooFingerprint(hash uint64) uint16 {
+	return uint16(hash>>(64-cuckooFingerprintSizeBits)%(cuckooMaxFingerprint-1) + 1)
+}
+
+// CuckooBucketCollisions returns n distinct keys that map to the same primary and alternate bucket of
+// panmari/cuckoofilter with at most 2^bucketBits buckets. Two buckets hold 8 fingerprints, every next insert
+// relocates fingerprints until the filter gives up. Cost grows with 4^bucketBits per key.
+// It fails when lengths and chars can not produce n such keys.
+func (g *Generator) CuckooBucketCollisions(n, bucketBits int, lengths LengthDist, chars []rune) ([]string, error) {
+	mask := uint64(1)<<bucketBits - 1
+
+	// Alternate bucket depends only on the fingerprint, so fingerprints with the same alternate offset are collected first.
+	var (
+		offset     [2]byte
+		sameOffset [cuckooMaxFingerprint]bool
+	)
+
+	for fp := uint16(1); fp < cuckooMaxFingerprint; fp++ {
+		binary.LittleEndian.PutUint16(offset[:], fp)
+
+		sameOffset[fp] = metro.Hash64(offset[:], cuckooHashSeed)&mask == mask
+	}
+
+	var (
+		bucket = g.Uint64() & mask
+		keys   = make([]string, 0, n)
+		seen   = make(map[string]struct{}, n)
+		limit  = maxRejections(2 * bucketBits)
+	)
+
+	for rejected := 0; len(keys) < n; {
+		if rejected == limit {
+			return nil, fmt.Errorf("%w: %d consecutive rejected candidates after %d of %d keys", ErrKeySpaceExhausted, rejected, len(keys), n)
+		}
+		rejected++
+
+		key := g.StringOf(lengths, chars)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		hash := metro.Hash64Str(key, cuckooHashSeed)
+		if hash&mask != bucket || !sameOffset[cuckooFingerprint(hash)] {
+			continue
+		}
+
+		rejected = 0
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
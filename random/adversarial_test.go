@@ -0,0 +1,149 @@
+package random
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	cuckoo "github.com/panmari/cuckoofilter"
+
+	"code.local/go-benchmarks/charhashmatrix"
+)
+
+func TestHashPrefixCollisions(t *testing.T) {
+	keys, err := NewPCG(DefaultSeed).HashPrefixCollisions(64, 3, KubernetesNameLengths, KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("HashPrefixCollisions returned an error: %v", err)
+	}
+
+	if len(keys) != 64 || len(distinct(keys)) != 64 {
+		t.Fatalf("Expected 64 distinct keys, got %d", len(distinct(keys)))
+	}
+
+	prefix := hashHex(keys[0])[:3]
+
+	for _, key := range keys {
+		if !strings.HasPrefix(hashHex(key), prefix) {
+			t.Fatalf("Hash of %q does not start with %s", key, prefix)
+		}
+	}
+}
+
+func TestHashRowSaturation(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	m := charhashmatrix.NewMatrix()
+
+	keys, err := g.HashRowSaturation(KubernetesNameLengths, KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("HashRowSaturation returned an error: %v", err)
+	}
+
+	for _, key := range keys {
+		if err := m.Set(key); err != nil {
+			t.Fatalf("Set returned an error: %v", err)
+		}
+	}
+
+	absent, err := g.UniqueStrings(1000, KubernetesNameLengths, KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("UniqueStrings returned an error: %v", err)
+	}
+
+	for _, key := range absent {
+		if len(hashHex(key)) == hashHexLength && !m.Contains(key) {
+			t.Fatalf("Saturated matrix does not contain %q", key)
+		}
+	}
+}
+
+func TestPrefixChain(t *testing.T) {
+	keys := NewPCG(DefaultSeed).PrefixChain(10, 3, KubernetesNamesAllowedChars)
+
+	for i, key := range keys {
+		if len(key) != (i+1)*3 || (i > 0 && !strings.HasPrefix(key, keys[i-1])) {
+			t.Fatalf("Unexpected chain %q", keys)
+		}
+	}
+}
+
+func TestLongCommonPrefix(t *testing.T) {
+	keys, err := NewPCG(DefaultSeed).LongCommonPrefix(100, 200, 4, KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("LongCommonPrefix returned an error: %v", err)
+	}
+
+	if len(distinct(keys)) != 100 {
+		t.Fatal("LongCommonPrefix returned duplicates")
+	}
+
+	for _, key := range keys {
+		if len(key) != 204 || key[:200] != keys[0][:200] {
+			t.Fatalf("Unexpected key %q", key)
+		}
+	}
+}
+
+func TestNodeChurn(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	keys, err := g.NodeChurn(8, 49, 6, ASCIIPrintableChars)
+	if err != nil {
+		t.Fatalf("NodeChurn returned an error: %v", err)
+	}
+
+	if len(keys) != 8*49 || len(distinct(keys)) != len(keys) {
+		t.Fatalf("Expected %d distinct keys, got %d", 8*49, len(distinct(keys)))
+	}
+
+	for i, key := range keys {
+		if len(key) != 7 || key[:6] != keys[i/49*49][:6] {
+			t.Fatalf("Unexpected key %q in group %d", key, i/49)
+		}
+	}
+
+	if _, err := g.NodeChurn(1, 40, 6, KubernetesNamesAllowedChars); err == nil {
+		t.Fatal("Expected an error for fanout above number of characters")
+	}
+}
+
+func TestCuckooBucketCollisions(t *testing.T) {
+	const bucketBits = 6
+
+	keys, err := NewPCG(DefaultSeed).CuckooBucketCollisions(9, bucketBits, KubernetesNameLengths, KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("CuckooBucketCollisions returned an error: %v", err)
+	}
+
+	// Keys collide in any filter with at most 2^bucketBits buckets of 4 fingerprints.
+	cf := cuckoo.NewFilter(1 << bucketBits)
+
+	for _, key := range keys[:8] {
+		if !cf.Insert([]byte(key)) {
+			t.Fatalf("Insert of %q failed before both buckets are full", key)
+		}
+	}
+
+	if cf.Insert([]byte(keys[8])) {
+		t.Fatal("Insert succeeded although both buckets are full")
+	}
+}
+
+func TestAdversarialKeySpaceExhausted(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	// Two characters of a single length are 2 keys, too few for any of the generators.
+	lengths, chars := Uniform{Min: 1, Max: 1}, []rune("ab")
+
+	if _, err := g.HashPrefixCollisions(3, 1, lengths, chars); !errors.Is(err, ErrKeySpaceExhausted) {
+		t.Fatalf("Expected ErrKeySpaceExhausted from HashPrefixCollisions, got %v", err)
+	}
+
+	if _, err := g.HashRowSaturation(lengths, chars); !errors.Is(err, ErrKeySpaceExhausted) {
+		t.Fatalf("Expected ErrKeySpaceExhausted from HashRowSaturation, got %v", err)
+	}
+
+	if _, err := g.CuckooBucketCollisions(3, 1, lengths, chars); !errors.Is(err, ErrKeySpaceExhausted) {
+		t.Fatalf("Expected ErrKeySpaceExhausted from CuckooBucketCollisions, got %v", err)
+	}
+}
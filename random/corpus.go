@@ -0,0 +1,181 @@
+package random
+
+import (
+	"fmt"
+	"slices"
+)
+
+// GeneratedNameChars are used by Kubernetes for generated name suffixes, vowels and confusable characters are omitted.
+var GeneratedNameChars = []rune("bcdfghjklmnpqrstvwxz2456789")
+
+// CorpusLevel is one level of the key hierarchy, every key of the previous level is extended by Fanout segments.
+type CorpusLevel struct {
+	Fanout int
+
+	// Segment returns the i-th segment appended to a parent key, including its separator.
+	// Segments of siblings are expected to be distinct, duplicates are regenerated.
+	Segment func(g *Generator, i int) string
+}
+
+// Sequence returns a segment function formatting sibling index, e.g. Sequence("/deploy-%d").
+func Sequence(format string) func(g *Generator, i int) string {
+	return func(_ *Generator, i int) string {
+		return fmt.Sprintf(format, i)
+	}
+}
+
+// GeneratedSuffix returns a segment function of separator and size random GeneratedNameChars,
+// as ReplicaSet pod-template-hash or Pod name suffix.
+func GeneratedSuffix(separator string, size int) func(g *Generator, i int) string {
+	return func(g *Generator, _ int) string {
+		return separator + g.String(size, GeneratedNameChars)
+	}
+}
+
+// Corpus generates hierarchical keys with controlled prefix sharing.
+type Corpus struct {
+	Levels []CorpusLevel
+
+	// CommonPrefixRatio is the proportion of keys that keep their hierarchical form, others are replaced
+	// by random keys of the same length, so 1 means fully hierarchical and 0 means no intended shared prefixes.
+	CommonPrefixRatio float64
+}
+
+// KubernetesPods returns a corpus of `ns-N/deploy-M-<hash>-<pod-suffix>` keys of Pods owned by ReplicaSets
+// of Deployments, with every key in its hierarchical form.
+func KubernetesPods(namespaces, deployments, replicaSets, pods int) Corpus {
+	return Corpus{
+		Levels: []CorpusLevel{
+			{Fanout: namespaces, Segment: Sequence("ns-%d")},
+			{Fanout: deployments, Segment: Sequence("/deploy-%d")},
+			{Fanout: replicaSets, Segment: GeneratedSuffix("-", 10)},
+			{Fanout: pods, Segment: GeneratedSuffix("-", 5)},
+		},
+		CommonPrefixRatio: 1,
+	}
+}
+
+// Size returns the number of generated keys, product of fan-outs of all levels.
+func (c Corpus) Size() int {
+	size := 1
+
+	for _, level := range c.Levels {
+		size *= level.Fanout
+	}
+
+	return size
+}
+
+// Generate returns Size distinct keys in depth-first order, same generator state always produces the same keys.
+// It fails when a level can not produce Fanout distinct segments, e.g. GeneratedSuffix of too few characters.
+func (c Corpus) Generate(g *Generator) ([]string, error) {
+	keys := []string{""}
+
+	for depth, level := range c.Levels {
+		next := make([]string, 0, len(keys)*level.Fanout)
+
+		for _, parent := range keys {
+			siblings := make(map[string]struct{}, level.Fanout)
+
+			for i := 0; i < level.Fanout; i++ {
+				segment := level.Segment(g, i)
+
+				for duplicates := 0; contains(siblings, segment); duplicates++ {
+					if duplicates == maxDuplicates {
+						return nil, fmt.Errorf("%w: level %d has %d distinct of %d segments", ErrKeySpaceExhausted, depth, i, level.Fanout)
+					}
+
+					segment = level.Segment(g, i)
+				}
+
+				siblings[segment] = struct{}{}
+				next = append(next, parent+segment)
+			}
+		}
+
+		keys = next
+	}
+
+	if c.CommonPrefixRatio >= 1 {
+		return keys, nil
+	}
+
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		seen[key] = struct{}{}
+	}
+
+	for i, key := range keys {
+		if g.Float64() < c.CommonPrefixRatio {
+			continue
+		}
+
+		flat := g.String(len(key), dns1123LabelChars)
+
+		for duplicates := 0; contains(seen, flat); duplicates++ {
+			if duplicates == maxDuplicates {
+				return nil, fmt.Errorf("%w: no random key of length %d replaces %q", ErrKeySpaceExhausted, len(key), key)
+			}
+
+			flat = g.String(len(key), dns1123LabelChars)
+		}
+
+		delete(seen, key)
+		seen[flat] = struct{}{}
+		keys[i] = flat
+	}
+
+	return keys, nil
+}
+
+func contains(set map[string]struct{}, key string) bool {
+	_, ok := set[key]
+
+	return ok
+}
+
+// SharedPrefixRatio returns the proportion of characters of keys that are shared with the lexically previous
+// or next key, i.e. how much of the key set a trie can store only once.
+func SharedPrefixRatio(keys []string) float64 {
+	if len(keys) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+
+	var shared, total int
+
+	for i, key := range sorted {
+		longest := 0
+
+		if i > 0 {
+			longest = commonPrefixLength(key, sorted[i-1])
+		}
+
+		if i+1 < len(sorted) {
+			longest = max(longest, commonPrefixLength(key, sorted[i+1]))
+		}
+
+		shared += longest
+		total += len(key)
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(shared) / float64(total)
+}
+
+func commonPrefixLength(a, b string) int {
+	n := min(len(a), len(b))
+
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+
+	return n
+}
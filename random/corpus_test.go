@@ -0,0 +1,107 @@
+package random
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestKubernetesPods(t *testing.T) {
+	c := KubernetesPods(3, 4, 2, 5)
+
+	keys, err := c.Generate(NewPCG(DefaultSeed))
+	if err != nil {
+		t.Fatalf("Generate returned an error: %v", err)
+	}
+
+	if len(keys) != c.Size() || c.Size() != 3*4*2*5 {
+		t.Fatalf("Expected %d keys, got %d", c.Size(), len(keys))
+	}
+
+	pattern := regexp.MustCompile(`^ns-[0-9]+/deploy-[0-9]+-[bcdfghjklmnpqrstvwxz2456789]{10}-[bcdfghjklmnpqrstvwxz2456789]{5}$`)
+
+	seen := make(map[string]struct{}, len(keys))
+
+	for _, key := range keys {
+		if !pattern.MatchString(key) {
+			t.Fatalf("Unexpected key %q", key)
+		}
+
+		if _, ok := seen[key]; ok {
+			t.Fatalf("Duplicate key %q", key)
+		}
+
+		seen[key] = struct{}{}
+	}
+
+	// Keys are in depth-first order, so every namespace is contiguous.
+	if keys[0][:5] != "ns-0/" || keys[len(keys)-1][:5] != "ns-2/" {
+		t.Errorf("Unexpected order: %q ... %q", keys[0], keys[len(keys)-1])
+	}
+
+	if again, _ := c.Generate(NewPCG(DefaultSeed)); !slices.Equal(keys, again) {
+		t.Error("Same seed produced different keys")
+	}
+}
+
+func TestCorpusCommonPrefixRatio(t *testing.T) {
+	previous := -1.0
+
+	for _, ratio := range []float64{0, 0.5, 1} {
+		c := KubernetesPods(4, 8, 2, 16)
+		c.CommonPrefixRatio = ratio
+
+		keys, err := c.Generate(NewPCG(DefaultSeed))
+		if err != nil {
+			t.Fatalf("Generate returned an error for ratio %v: %v", ratio, err)
+		}
+
+		seen := make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			seen[key] = struct{}{}
+		}
+
+		if len(seen) != c.Size() {
+			t.Fatalf("Expected %d distinct keys for ratio %v, got %d", c.Size(), ratio, len(seen))
+		}
+
+		shared := SharedPrefixRatio(keys)
+		if shared <= previous {
+			t.Errorf("Shared prefix ratio %v for common prefix ratio %v is not above %v", shared, ratio, previous)
+		}
+
+		previous = shared
+	}
+}
+
+func TestCorpusKeySpaceExhausted(t *testing.T) {
+	for name, c := range map[string]Corpus{
+		"constant segment":      {Levels: []CorpusLevel{{Fanout: 2, Segment: func(*Generator, int) string { return "ns" }}}, CommonPrefixRatio: 1},
+		"fanout above suffixes": {Levels: []CorpusLevel{{Fanout: len(GeneratedNameChars) + 1, Segment: GeneratedSuffix("-", 1)}}, CommonPrefixRatio: 1},
+		"flat keys":             {Levels: []CorpusLevel{{Fanout: 64, Segment: Sequence("%c")}}, CommonPrefixRatio: 0}, // 64 single-byte keys
+	} {
+		if _, err := c.Generate(NewPCG(DefaultSeed)); !errors.Is(err, ErrKeySpaceExhausted) {
+			t.Errorf("Expected ErrKeySpaceExhausted for %s, got %v", name, err)
+		}
+	}
+}
+
+func TestSharedPrefixRatio(t *testing.T) {
+	tests := []struct {
+		keys     []string
+		expected float64
+	}{
+		{nil, 0},
+		{[]string{"abc"}, 0},
+		{[]string{"abc", "xyz"}, 0},
+		{[]string{"ab", "ab"}, 1},
+		{[]string{"abcd", "abxy", "zzzz"}, 4.0 / 12},
+	}
+
+	for _, tc := range tests {
+		if got := SharedPrefixRatio(tc.keys); got != tc.expected {
+			t.Errorf("SharedPrefixRatio(%q) mismatch: expected %v, got %v", tc.keys, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package random
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+)
+
+// DefaultSeed is used when no seed is given explicitly, so runs are reproducible by default.
+const DefaultSeed uint64 = 1
+
+type Source string
+
+const (
+	PCG     Source = "pcg"
+	ChaCha8 Source = "chacha8"
+)
+
+// Generator is a seeded, reproducible random generator, same source, seed and stream always
+// produce the same sequence. Generator is not safe for concurrent use, see Stream.
+type Generator struct {
+	*rand.Rand
+
+	source Source
+	seed   uint64
+	stream uint64
+}
+
+func NewPCG(seed uint64) *Generator {
+	return newGenerator(PCG, seed, 0)
+}
+
+func NewChaCha8(seed uint64) *Generator {
+	return newGenerator(ChaCha8, seed, 0)
+}
+
+func New(source Source, seed uint64) (*Generator, error) {
+	switch source {
+	case PCG, ChaCha8:
+		return newGenerator(source, seed, 0), nil
+	default:
+		return nil, fmt.Errorf("unknown random source %q", source)
+	}
+}
+
+func newGenerator(source Source, seed, stream uint64) *Generator {
+	g := &Generator{
+		source: source,
+		seed:   seed,
+		stream: stream,
+	}
+
+	switch source {
+	case ChaCha8:
+		var key [32]byte
+
+		binary.LittleEndian.PutUint64(key[0:], seed)
+		binary.LittleEndian.PutUint64(key[8:], stream)
+
+		g.Rand = rand.New(rand.NewChaCha8(key))
+	default:
+		g.Rand = rand.New(rand.NewPCG(seed, stream))
+	}
+
+	return g
+}
+
+func (g *Generator) Source() Source {
+	return g.source
+}
+
+func (g *Generator) Seed() uint64 {
+	return g.seed
+}
+
+// Stream returns an independent generator of the same source and seed, e.g. one per goroutine.
+func (g *Generator) Stream(stream uint64) *Generator {
+	return newGenerator(g.source, g.seed, stream)
+}
@@ -0,0 +1,53 @@
+package random
+
+import (
+	"testing"
+)
+
+func TestGeneratorIsReproducible(t *testing.T) {
+	for _, source := range []Source{PCG, ChaCha8} {
+		t.Run(string(source), func(t *testing.T) {
+			a, err := New(source, 42)
+			if err != nil {
+				t.Fatalf("New returned an error: %v", err)
+			}
+
+			b, _ := New(source, 42)
+			c, _ := New(source, 43)
+
+			for i := 0; i < 100; i++ {
+				s := a.String(32, KubernetesNamesAllowedChars)
+
+				if expected := b.String(32, KubernetesNamesAllowedChars); s != expected {
+					t.Fatalf("Same seed produced different strings: %q and %q", s, expected)
+				}
+
+				if s == c.String(32, KubernetesNamesAllowedChars) {
+					t.Fatalf("Different seeds produced the same string %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestGeneratorStream(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	if g.Stream(1).Uint64() != g.Stream(1).Uint64() {
+		t.Fatal("Same stream produced different values")
+	}
+
+	if g.Stream(1).Uint64() == g.Stream(2).Uint64() {
+		t.Fatal("Different streams produced the same value")
+	}
+
+	if NewPCG(DefaultSeed).Uint64() != g.Stream(0).Uint64() {
+		t.Fatal("Stream 0 differs from the generator itself")
+	}
+}
+
+func TestNewUnknownSource(t *testing.T) {
+	if _, err := New("unknown", DefaultSeed); err == nil {
+		t.Fatal("Expected an error for unknown source")
+	}
+}
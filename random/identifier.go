@@ -0,0 +1,175 @@
+package random
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+var (
+	// Base32Chars is the lowercase RFC 4648 alphabet, valid in Kubernetes names.
+	Base32Chars = []rune("abcdefghijklmnopqrstuvwxyz234567")
+	// Base58Chars is the Bitcoin alphabet, without 0, O, I and l.
+	Base58Chars = []rune("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+	// crockfordBase32 is used by ULID, it is ordered, so encoded IDs sort as their values.
+	crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// FilePathDepths approximates number of components of paths in source trees and container images.
+var FilePathDepths LengthDist = Normal{Mean: 5, StdDev: 2, Min: 1, Max: 32}
+
+func formatUUID(b [16]byte) string {
+	var buf [36]byte
+
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+
+	return string(buf[:])
+}
+
+// UUIDv4 returns a random UUID, see RFC 9562.
+func (g *Generator) UUIDv4() string {
+	var b [16]byte
+
+	binary.BigEndian.PutUint64(b[0:], g.Uint64())
+	binary.BigEndian.PutUint64(b[8:], g.Uint64())
+
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+
+	return formatUUID(b)
+}
+
+// UUIDv7 returns a time-ordered UUID of t with millisecond precision followed by random bits, see RFC 9562.
+func (g *Generator) UUIDv7(t time.Time) string {
+	var b [16]byte
+
+	binary.BigEndian.PutUint64(b[0:], uint64(t.UnixMilli())<<16|g.Uint64()&0xffff)
+	binary.BigEndian.PutUint64(b[8:], g.Uint64())
+
+	b[6] = b[6]&0x0f | 0x70 // version 7
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+
+	return formatUUID(b)
+}
+
+// ULID returns a lexicographically sortable identifier of t with millisecond precision followed by
+// 80 random bits, encoded to 26 characters of Crockford's base32, see https://github.com/ulid/spec.
+func (g *Generator) ULID(t time.Time) string {
+	var (
+		buf [26]byte
+
+		hi = uint64(t.UnixMilli())<<16 | g.Uint64()&0xffff // 48-bit time and 16 random bits
+		lo = g.Uint64()                                    // 64 random bits
+	)
+
+	// 128 bits are encoded as 26 5-bit characters, the first one holds only 3 bits.
+	for i := len(buf) - 1; i >= 0; i-- {
+		buf[i] = crockfordBase32[lo&0x1f]
+
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(buf[:])
+}
+
+// Base32ID returns a random identifier of size Base32Chars.
+func (g *Generator) Base32ID(size int) string {
+	return g.String(size, Base32Chars)
+}
+
+// Base58ID returns a random identifier of size Base58Chars.
+func (g *Generator) Base58ID(size int) string {
+	return g.String(size, Base58Chars)
+}
+
+// TimeOrdered returns n identifiers of increasing times from start, intervals between them are exponentially
+// distributed with the given mean, e.g. g.TimeOrdered(n, start, time.Millisecond, g.UUIDv7).
+func (g *Generator) TimeOrdered(n int, start time.Time, interval time.Duration, id func(t time.Time) string) []string {
+	ids := make([]string, n)
+
+	t := start
+
+	for i := range ids {
+		ids[i] = id(t)
+
+		t = t.Add(time.Duration(g.ExpFloat64() * float64(interval)))
+	}
+
+	return ids
+}
+
+var (
+	topLevelDomains = []string{"com", "net", "org", "io", "dev", "cloud", "internal", "local", "de", "uk"}
+	hostRoles       = []string{"api", "web", "db", "cache", "worker", "node", "ingress", "mail", "vpn", "monitoring"}
+	regions         = []string{"us-east-1", "us-west-2", "eu-west-1", "eu-central-1", "ap-southeast-1", "dc1", "dc2"}
+)
+
+// maxFQDNDepth fits the longest labels of FQDN below the top-level domain into DNS-1123 subdomain length.
+const maxFQDNDepth = 16
+
+// FQDN returns a fully qualified hostname of depth labels below the top-level domain, like
+// `api-7.eu-west-1.prod.example.com`, depth is clamped to [1, 16] to fit DNS-1123 subdomain length.
+// Host labels are roles with a random suffix, others are regions or random DNS-1123 labels.
+func (g *Generator) FQDN(depth int) string {
+	depth = min(max(depth, 1), maxFQDNDepth)
+
+	labels := make([]string, 0, depth+1)
+
+	labels = append(labels, hostRoles[g.IntN(len(hostRoles))]+"-"+g.DNS1123Label(g.IntN(3)+1))
+
+	for i := 1; i < depth; i++ {
+		if i == 1 && depth > 2 && g.IntN(2) == 0 {
+			labels = append(labels, regions[g.IntN(len(regions))])
+
+			continue
+		}
+
+		labels = append(labels, g.DNS1123Label(g.IntN(11)+2))
+	}
+
+	labels = append(labels, topLevelDomains[g.IntN(len(topLevelDomains))])
+
+	return strings.Join(labels, ".")
+}
+
+var (
+	directoryNames = []string{"usr", "lib", "var", "etc", "opt", "home", "src", "pkg", "internal", "cmd", "vendor",
+		"node_modules", "test", "data", "config", "docs", "assets", "build", "bin", "share", "log", "cache"}
+	fileExtensions = []string{"go", "json", "yaml", "txt", "md", "js", "ts", "py", "so", "log", "conf", "sh"}
+)
+
+// FilePath returns an absolute slash-separated path with number of components drawn from depths,
+// directories are common names mixed with random ones and the last component is a file with extension.
+func (g *Generator) FilePath(depths LengthDist) string {
+	depth := max(depths.Length(g), 1)
+
+	var sb strings.Builder
+
+	for i := 0; i < depth-1; i++ {
+		sb.WriteByte('/')
+
+		if g.IntN(3) == 0 {
+			sb.WriteString(g.DNS1123Label(g.IntN(12) + 1))
+		} else {
+			sb.WriteString(directoryNames[g.IntN(len(directoryNames))])
+		}
+	}
+
+	sb.WriteByte('/')
+	sb.WriteString(g.DNS1123Label(g.IntN(16) + 1))
+	sb.WriteByte('.')
+	sb.WriteString(fileExtensions[g.IntN(len(fileExtensions))])
+
+	return sb.String()
+}
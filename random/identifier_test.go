@@ -0,0 +1,123 @@
+package random
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	uuidV7 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulid   = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+)
+
+func TestUUIDv4(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	for i := 0; i < 1000; i++ {
+		if id := g.UUIDv4(); !uuidV4.MatchString(id) {
+			t.Fatalf("Invalid UUIDv4 %q", id)
+		}
+	}
+}
+
+func TestTimeOrdered(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		id     func(time.Time) string
+		format *regexp.Regexp
+	}{
+		{"uuid-v7", g.UUIDv7, uuidV7},
+		{"ulid", g.ULID, ulid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := g.TimeOrdered(1000, start, 10*time.Millisecond, tt.id)
+
+			for i, id := range ids {
+				if !tt.format.MatchString(id) {
+					t.Fatalf("Invalid identifier %q", id)
+				}
+
+				// Identifiers of the same millisecond are ordered only by random bits.
+				if i > 0 && id[:10] < ids[i-1][:10] {
+					t.Fatalf("Identifier %q is before previous %q", id, ids[i-1])
+				}
+			}
+		})
+	}
+}
+
+func TestULIDTimestamp(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	// Example of the ULID specification, 1469918176385 ms is encoded as 01ARYZ6S41.
+	if id := g.ULID(time.UnixMilli(1469918176385)); id[:10] != "01ARYZ6S41" {
+		t.Fatalf("Unexpected timestamp of %q", id)
+	}
+
+	if id := g.UUIDv7(time.UnixMilli(0x017f22e279b0)); !strings.HasPrefix(id, "017f22e2-79b0-7") {
+		t.Fatalf("Unexpected timestamp of %q", id)
+	}
+}
+
+func TestBaseIDs(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	for i := 0; i < 100; i++ {
+		if id := g.Base32ID(26); len(id) != 26 || strings.Trim(id, string(Base32Chars)) != "" {
+			t.Fatalf("Invalid base32 identifier %q", id)
+		}
+
+		if id := g.Base58ID(22); len(id) != 22 || strings.ContainsAny(id, "0OIl") {
+			t.Fatalf("Invalid base58 identifier %q", id)
+		}
+	}
+}
+
+func TestFQDN(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	// Depths out of [1, 16] are clamped.
+	for depth := -1; depth <= 20; depth++ {
+		expected := min(max(depth, 1), 16) + 1
+
+		for i := 0; i < 100; i++ {
+			name := g.FQDN(depth)
+
+			if err := ValidateDNS1123Subdomain(name); err != nil {
+				t.Fatalf("Invalid hostname %q: %v", name, err)
+			}
+
+			if labels := strings.Count(name, ".") + 1; labels != expected {
+				t.Fatalf("Hostname %q has %d labels, expected %d", name, labels, expected)
+			}
+		}
+	}
+}
+
+func TestFilePath(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	sum := 0
+
+	for i := 0; i < 1000; i++ {
+		path := g.FilePath(FilePathDepths)
+
+		if !strings.HasPrefix(path, "/") || strings.Contains(path, "//") || !strings.Contains(path[strings.LastIndex(path, "/"):], ".") {
+			t.Fatalf("Invalid path %q", path)
+		}
+
+		sum += strings.Count(path, "/")
+	}
+
+	if mean := float64(sum) / 1000; mean < 4 || mean > 6 {
+		t.Fatalf("Mean depth %v differs from FilePathDepths", mean)
+	}
+}
@@ -0,0 +1,170 @@
+package random
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Limits from https://kubernetes.io/docs/concepts/overview/working-with-objects/names/
+const (
+	DNS1123LabelMaxLength     = 63
+	DNS1123SubdomainMaxLength = 253
+)
+
+var (
+	ErrEmpty                 = errors.New("must be non-empty")
+	ErrTooLong               = errors.New("too long")
+	ErrInvalidCharacter      = errors.New("invalid character")
+	ErrInvalidStart          = errors.New("must start with an alphanumeric character")
+	ErrInvalidEnd            = errors.New("must end with an alphanumeric character")
+	ErrInvalidNamespacedName = errors.New("must be in namespace/name form")
+)
+
+var (
+	dns1123Alphanumerics = []rune("abcdefghijklmnopqrstuvwxyz0123456789")
+	dns1123LabelChars    = []rune("abcdefghijklmnopqrstuvwxyz0123456789-")
+)
+
+func isDNS1123Alphanumeric(c rune) bool {
+	return ('a' <= c && c <= 'z') || ('0' <= c && c <= '9')
+}
+
+// DNS1123Label returns a valid DNS-1123 label, size is clamped to [1, DNS1123LabelMaxLength].
+func (g *Generator) DNS1123Label(size int) string {
+	size = min(max(size, 1), DNS1123LabelMaxLength)
+
+	runes := g.Runes(size, dns1123LabelChars)
+
+	// First and last characters must be alphanumeric, '-' is allowed only inside.
+	runes[0] = dns1123Alphanumerics[g.IntN(len(dns1123Alphanumerics))]
+	runes[size-1] = dns1123Alphanumerics[g.IntN(len(dns1123Alphanumerics))]
+
+	return string(runes)
+}
+
+// DNS1123Subdomain returns a valid DNS-1123 subdomain of dot-separated labels,
+// size is clamped to [1, DNS1123SubdomainMaxLength].
+func (g *Generator) DNS1123Subdomain(size int) string {
+	size = min(max(size, 1), DNS1123SubdomainMaxLength)
+
+	var sb strings.Builder
+
+	sb.Grow(size)
+
+	for remaining := size; remaining > 0; {
+		n := min(remaining, g.IntN(DNS1123LabelMaxLength)+1)
+
+		// Label can not be followed by a single character, dot and a label of at least one character are needed.
+		if remaining-n == 1 {
+			if n > 1 {
+				n--
+			} else {
+				n++
+			}
+		}
+
+		sb.WriteString(g.DNS1123Label(n))
+
+		if remaining -= n; remaining > 0 {
+			sb.WriteByte('.')
+			remaining--
+		}
+	}
+
+	return sb.String()
+}
+
+// NamespacedName returns `namespace/name` key, where namespace is a DNS-1123 label and name is a DNS-1123 subdomain.
+func (g *Generator) NamespacedName(namespaceSize, nameSize int) string {
+	return g.DNS1123Label(namespaceSize) + "/" + g.DNS1123Subdomain(nameSize)
+}
+
+// validateDNS1123Characters checks characters of a non-empty label, positions count runes.
+func validateDNS1123Characters(s string) []error {
+	var errs []error
+
+	position := 0
+
+	for _, c := range s {
+		if !isDNS1123Alphanumeric(c) && c != '-' {
+			errs = append(errs, fmt.Errorf("%w %q at position %d", ErrInvalidCharacter, c, position))
+		}
+
+		position++
+	}
+
+	if c, _ := utf8.DecodeRuneInString(s); !isDNS1123Alphanumeric(c) {
+		errs = append(errs, fmt.Errorf("%w, got %q", ErrInvalidStart, c))
+	}
+
+	if c, _ := utf8.DecodeLastRuneInString(s); !isDNS1123Alphanumeric(c) {
+		errs = append(errs, fmt.Errorf("%w, got %q", ErrInvalidEnd, c))
+	}
+
+	return errs
+}
+
+// ValidateDNS1123Label returns nil for a valid DNS-1123 label, otherwise an error that joins all violations.
+func ValidateDNS1123Label(s string) error {
+	if len(s) == 0 {
+		return ErrEmpty
+	}
+
+	var errs []error
+
+	if len(s) > DNS1123LabelMaxLength {
+		errs = append(errs, fmt.Errorf("%w: %d characters, must be no more than %d", ErrTooLong, len(s), DNS1123LabelMaxLength))
+	}
+
+	return errors.Join(append(errs, validateDNS1123Characters(s)...)...)
+}
+
+// ValidateDNS1123Subdomain returns nil for a valid DNS-1123 subdomain, otherwise an error that joins all violations.
+// It follows Kubernetes, which limits only the total length, not length of every label.
+func ValidateDNS1123Subdomain(s string) error {
+	if len(s) == 0 {
+		return ErrEmpty
+	}
+
+	var errs []error
+
+	if len(s) > DNS1123SubdomainMaxLength {
+		errs = append(errs, fmt.Errorf("%w: %d characters, must be no more than %d", ErrTooLong, len(s), DNS1123SubdomainMaxLength))
+	}
+
+	for i, label := range strings.Split(s, ".") {
+		if len(label) == 0 {
+			errs = append(errs, fmt.Errorf("label %d: %w", i, ErrEmpty))
+
+			continue
+		}
+
+		for _, err := range validateDNS1123Characters(label) {
+			errs = append(errs, fmt.Errorf("label %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ValidateNamespacedName returns nil for a valid `namespace/name` key, otherwise an error that joins all violations.
+func ValidateNamespacedName(s string) error {
+	namespace, name, ok := strings.Cut(s, "/")
+	if !ok || strings.Contains(name, "/") {
+		return fmt.Errorf("%w, got %q", ErrInvalidNamespacedName, s)
+	}
+
+	var errs []error
+
+	if err := ValidateDNS1123Label(namespace); err != nil {
+		errs = append(errs, fmt.Errorf("namespace: %w", err))
+	}
+
+	if err := ValidateDNS1123Subdomain(name); err != nil {
+		errs = append(errs, fmt.Errorf("name: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,98 @@
+package random
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDNS1123Generators(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	for size := 0; size <= DNS1123SubdomainMaxLength+1; size++ {
+		if label := g.DNS1123Label(size); len(label) != min(max(size, 1), DNS1123LabelMaxLength) {
+			t.Fatalf("Label %q has unexpected length for size %d", label, size)
+		} else if err := ValidateDNS1123Label(label); err != nil {
+			t.Fatalf("Generated invalid label %q: %v", label, err)
+		}
+
+		subdomain := g.DNS1123Subdomain(size)
+		if len(subdomain) != min(max(size, 1), DNS1123SubdomainMaxLength) {
+			t.Fatalf("Subdomain %q has unexpected length for size %d", subdomain, size)
+		}
+
+		if err := ValidateDNS1123Subdomain(subdomain); err != nil {
+			t.Fatalf("Generated invalid subdomain %q: %v", subdomain, err)
+		}
+
+		for _, label := range strings.Split(subdomain, ".") {
+			if err := ValidateDNS1123Label(label); err != nil {
+				t.Fatalf("Generated subdomain %q with invalid label: %v", subdomain, err)
+			}
+		}
+
+		if name := g.NamespacedName(size, size); ValidateNamespacedName(name) != nil {
+			t.Fatalf("Generated invalid namespaced name %q: %v", name, ValidateNamespacedName(name))
+		}
+	}
+}
+
+func TestValidateDNS1123(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) error
+		value    string
+		expected []error
+	}{
+		{"valid label", ValidateDNS1123Label, "my-name-1", nil},
+		{"empty label", ValidateDNS1123Label, "", []error{ErrEmpty}},
+		{"long label", ValidateDNS1123Label, strings.Repeat("a", 64), []error{ErrTooLong}},
+		{"label with dot", ValidateDNS1123Label, "a.b", []error{ErrInvalidCharacter}},
+		{"uppercase label", ValidateDNS1123Label, "Name", []error{ErrInvalidCharacter, ErrInvalidStart}},
+		{"label with dashes", ValidateDNS1123Label, "-name-", []error{ErrInvalidStart, ErrInvalidEnd}},
+		{"valid subdomain", ValidateDNS1123Subdomain, "example.com", nil},
+		{"subdomain with long label", ValidateDNS1123Subdomain, strings.Repeat("a", 64) + ".com", nil},
+		{"long subdomain", ValidateDNS1123Subdomain, strings.Repeat("a.", 127), []error{ErrTooLong, ErrEmpty}},
+		{"pool garbage", ValidateDNS1123Subdomain, "-./..", []error{ErrInvalidCharacter, ErrInvalidStart, ErrEmpty}},
+		{"valid namespaced name", ValidateNamespacedName, "default/kube-dns.v1", nil},
+		{"missing namespace", ValidateNamespacedName, "kube-dns", []error{ErrInvalidNamespacedName}},
+		{"nested name", ValidateNamespacedName, "a/b/c", []error{ErrInvalidNamespacedName}},
+		{"invalid namespace", ValidateNamespacedName, "kube.system/dns", []error{ErrInvalidCharacter}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.validate(tc.value)
+
+			if tc.expected == nil && err != nil {
+				t.Fatalf("Unexpected error for %q: %v", tc.value, err)
+			}
+
+			if tc.expected != nil && err == nil {
+				t.Fatalf("Expected an error for %q", tc.value)
+			}
+
+			for _, expected := range tc.expected {
+				if !errors.Is(err, expected) {
+					t.Errorf("Expected %v for %q, got %v", expected, tc.value, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDNS1123Messages(t *testing.T) {
+	err := ValidateDNS1123Label("é-ä-é")
+
+	for _, expected := range []string{
+		`invalid character 'é' at position 0`,
+		`invalid character 'ä' at position 2`,
+		`invalid character 'é' at position 4`,
+		`must start with an alphanumeric character, got 'é'`,
+		`must end with an alphanumeric character, got 'é'`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q in %v", expected, err)
+		}
+	}
+}
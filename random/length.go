@@ -0,0 +1,240 @@
+package random
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxResamples bounds rejection sampling of truncated distributions, values are clamped afterwards.
+const maxResamples = 64
+
+// LengthDist draws string lengths from a distribution using the given generator.
+type LengthDist interface {
+	Length(g *Generator) int
+}
+
+// KubernetesNameLengths approximates lengths of real Kubernetes object names, which cluster around 10-40 characters.
+var KubernetesNameLengths LengthDist = LogNormal{Mu: math.Log(20), Sigma: 0.35, Min: 1, Max: DNS1123SubdomainMaxLength}
+
+// Uniform draws lengths uniformly from [Min, Max].
+type Uniform struct {
+	Min, Max int
+}
+
+// NewUniform returns a uniform distribution of lengths from [minLength, maxLength].
+func NewUniform(minLength, maxLength int) (Uniform, error) {
+	if err := checkRange(minLength, maxLength); err != nil {
+		return Uniform{}, err
+	}
+
+	return Uniform{Min: minLength, Max: maxLength}, nil
+}
+
+func (d Uniform) Length(g *Generator) int {
+	return d.Min + g.IntN(d.Max-d.Min+1)
+}
+
+// Normal draws lengths from a normal distribution truncated to [Min, Max].
+type Normal struct {
+	Mean, StdDev float64
+	Min, Max     int
+}
+
+func (d Normal) Length(g *Generator) int {
+	return truncate(d.Min, d.Max, func() float64 {
+		return d.Mean + d.StdDev*g.NormFloat64()
+	})
+}
+
+// LogNormal draws lengths from a log-normal distribution, where logarithm of length has mean Mu and standard
+// deviation Sigma, truncated to [Min, Max]. Median length is e^Mu.
+type LogNormal struct {
+	Mu, Sigma float64
+	Min, Max  int
+}
+
+func (d LogNormal) Length(g *Generator) int {
+	return truncate(d.Min, d.Max, func() float64 {
+		return math.Exp(d.Mu + d.Sigma*g.NormFloat64())
+	})
+}
+
+func truncate(minLength, maxLength int, sample func() float64) int {
+	var length int
+
+	for i := 0; i < maxResamples; i++ {
+		if length = int(math.Round(sample())); minLength <= length && length <= maxLength {
+			return length
+		}
+	}
+
+	return min(max(length, minLength), maxLength)
+}
+
+// Zipf draws lengths from [Min, Max] where Min is the most frequent, see rand.NewZipf.
+// It caches rand.Zipf of the last generator, so it is not safe for concurrent use.
+type Zipf struct {
+	s, v                 float64
+	minLength, maxLength int
+
+	rand *rand.Rand
+	zipf *rand.Zipf
+}
+
+// NewZipf returns a Zipf distribution of lengths from [minLength, maxLength] with s > 1 and v >= 1.
+func NewZipf(s, v float64, minLength, maxLength int) (*Zipf, error) {
+	if !(s > 1) || !(v >= 1) {
+		return nil, fmt.Errorf("invalid Zipf parameters s=%v, v=%v, expected s > 1 and v >= 1", s, v)
+	}
+
+	if err := checkRange(minLength, maxLength); err != nil {
+		return nil, err
+	}
+
+	return &Zipf{s: s, v: v, minLength: minLength, maxLength: maxLength}, nil
+}
+
+func (d *Zipf) Length(g *Generator) int {
+	if d.rand != g.Rand {
+		d.rand = g.Rand
+		d.zipf = rand.NewZipf(g.Rand, d.s, d.v, uint64(d.maxLength-d.minLength))
+	}
+
+	return d.minLength + int(d.zipf.Uint64())
+}
+
+func checkRange(minLength, maxLength int) error {
+	if minLength < 0 || maxLength < minLength {
+		return fmt.Errorf("invalid length range [%d, %d]", minLength, maxLength)
+	}
+
+	return nil
+}
+
+// Empirical draws lengths with frequencies of an observed histogram.
+type Empirical struct {
+	lengths    []int
+	cumulative []uint64
+}
+
+// NewEmpirical returns a distribution of lengths weighted by their observed counts.
+func NewEmpirical(counts map[int]uint64) (*Empirical, error) {
+	d := &Empirical{}
+
+	var total uint64
+
+	for _, length := range slices.Sorted(maps.Keys(counts)) {
+		if length < 0 {
+			return nil, fmt.Errorf("negative length %d", length)
+		}
+
+		if counts[length] == 0 {
+			continue
+		}
+
+		total += counts[length]
+
+		d.lengths = append(d.lengths, length)
+		d.cumulative = append(d.cumulative, total)
+	}
+
+	if total == 0 {
+		return nil, errors.New("no observed lengths")
+	}
+
+	return d, nil
+}
+
+// ReadEmpiricalCSV reads observed lengths, one `length` or `length,count` record per line. Header line is skipped.
+func ReadEmpiricalCSV(r io.Reader) (*Empirical, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	counts := make(map[int]uint64)
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		length, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+
+			return nil, fmt.Errorf("line %d: invalid length: %w", line, err)
+		}
+
+		count := uint64(1)
+
+		switch len(record) {
+		case 1:
+		case 2:
+			if count, err = strconv.ParseUint(strings.TrimSpace(record[1]), 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid count: %w", line, err)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: expected length and optional count, got %d fields", line, len(record))
+		}
+
+		counts[length] += count
+	}
+
+	return NewEmpirical(counts)
+}
+
+// LoadEmpiricalCSV reads observed lengths from a CSV file, see ReadEmpiricalCSV.
+func LoadEmpiricalCSV(path string) (*Empirical, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d, err := ReadEmpiricalCSV(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return d, nil
+}
+
+func (d *Empirical) Length(g *Generator) int {
+	n := g.Uint64N(d.cumulative[len(d.cumulative)-1])
+
+	return d.lengths[sort.Search(len(d.cumulative), func(i int) bool { return d.cumulative[i] > n })]
+}
+
+// Truncated limits lengths of another distribution to [Min, Max], resampling values out of range.
+type Truncated struct {
+	Dist     LengthDist
+	Min, Max int
+}
+
+func (d Truncated) Length(g *Generator) int {
+	return truncate(d.Min, d.Max, func() float64 {
+		return float64(d.Dist.Length(g))
+	})
+}
+
+// StringOf returns a string of chars with length drawn from dist.
+func (g *Generator) StringOf(dist LengthDist, chars []rune) string {
+	return g.String(dist.Length(g), chars)
+}
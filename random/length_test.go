@@ -0,0 +1,140 @@
+package random
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLengthDists(t *testing.T) {
+	const iterations = 10000
+
+	empirical, err := NewEmpirical(map[int]uint64{5: 1, 10: 3, 20: 0})
+	if err != nil {
+		t.Fatalf("NewEmpirical returned an error: %v", err)
+	}
+
+	zipf, err := NewZipf(1.5, 1, 3, 100)
+	if err != nil {
+		t.Fatalf("NewZipf returned an error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		dist     LengthDist
+		min, max int
+		median   int // expected median within 10%, skipped when zero
+	}{
+		{"uniform", Uniform{Min: 1, Max: 255}, 1, 255, 128},
+		{"normal", Normal{Mean: 30, StdDev: 10, Min: 1, Max: 63}, 1, 63, 30},
+		{"normal far from bounds", Normal{Mean: 1000, StdDev: 1, Min: 1, Max: 63}, 63, 63, 63},
+		{"log-normal", LogNormal{Mu: math.Log(20), Sigma: 0.5, Min: 1, Max: 253}, 1, 253, 20},
+		{"kubernetes", KubernetesNameLengths, 1, DNS1123SubdomainMaxLength, 20},
+		{"zipf", zipf, 3, 100, 0},
+		{"empirical", empirical, 5, 10, 10},
+		{"truncated", Truncated{Dist: Uniform{Min: 1, Max: 255}, Min: 10, Max: 20}, 10, 20, 15},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewPCG(DefaultSeed)
+
+			lengths := make([]int, iterations)
+			for i := range lengths {
+				lengths[i] = tc.dist.Length(g)
+
+				if lengths[i] < tc.min || lengths[i] > tc.max {
+					t.Fatalf("Length %d out of range [%d, %d]", lengths[i], tc.min, tc.max)
+				}
+			}
+
+			slices.Sort(lengths)
+
+			if median := lengths[iterations/2]; tc.median != 0 && math.Abs(float64(median-tc.median)) > float64(tc.median)/10 {
+				t.Errorf("Median mismatch: expected %d, got %d", tc.median, median)
+			}
+		})
+	}
+}
+
+func TestZipfPrefersMin(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	d, err := NewZipf(2, 1, 3, 100)
+	if err != nil {
+		t.Fatalf("NewZipf returned an error: %v", err)
+	}
+
+	counts := make(map[int]int)
+	for i := 0; i < 10000; i++ {
+		counts[d.Length(g)]++
+	}
+
+	if counts[3] <= counts[4] || counts[4] <= counts[5] {
+		t.Errorf("Expected decreasing frequencies, got %d, %d, %d", counts[3], counts[4], counts[5])
+	}
+}
+
+func TestInvalidLengthDists(t *testing.T) {
+	for _, tc := range []struct {
+		s, v     float64
+		min, max int
+	}{
+		{1, 1, 1, 10},
+		{2, 0.5, 1, 10},
+		{math.NaN(), 1, 1, 10},
+		{2, 1, 10, 1},
+		{2, 1, -1, 10},
+	} {
+		if _, err := NewZipf(tc.s, tc.v, tc.min, tc.max); err == nil {
+			t.Errorf("Expected an error for Zipf s=%v, v=%v, [%d, %d]", tc.s, tc.v, tc.min, tc.max)
+		}
+	}
+
+	if _, err := NewUniform(10, 1); err == nil {
+		t.Error("Expected an error for Uniform with Max below Min")
+	}
+
+	if d, err := NewUniform(3, 3); err != nil || d.Length(NewPCG(DefaultSeed)) != 3 {
+		t.Errorf("Unexpected Uniform %v: %v", d, err)
+	}
+}
+
+func TestReadEmpiricalCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		lengths []int
+		wantErr bool
+	}{
+		{"observations", "12\n30\n12\n", []int{12, 30}, false},
+		{"header and counts", "length,count\n12, 3\n30,1\n", []int{12, 30}, false},
+		{"invalid length", "12\nabc\n", nil, true},
+		{"invalid count", "12,x\n", nil, true},
+		{"too many fields", "12,1,1\n", nil, true},
+		{"empty", "length\n", nil, true},
+		{"negative", "-1\n", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := ReadEmpiricalCSV(strings.NewReader(tc.csv))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ReadEmpiricalCSV returned an error: %v", err)
+			}
+
+			if !slices.Equal(d.lengths, tc.lengths) {
+				t.Errorf("Lengths mismatch: expected %v, got %v", tc.lengths, d.lengths)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package randomtest
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"testing"
+
+	"code.local/go-benchmarks/random"
+)
+
+// SeedEnv is the environment variable that overrides the seed when -random.seed is not set.
+const SeedEnv = "RANDOM_SEED"
+
+var (
+	seedFlag   = flag.String("random.seed", "", "seed of random generators, overrides "+SeedEnv+" environment variable")
+	sourceFlag = flag.String("random.source", string(random.PCG), "source of random generators, pcg or chacha8")
+	lengthFlag = flag.String("random.lengths", "uniform", "distribution of generated string lengths, uniform, kubernetes or path to a CSV file of observed lengths")
+)
+
+// Seed returns the seed from -random.seed flag, RANDOM_SEED environment variable or random.DefaultSeed,
+// in that order, and logs it so a failing run can be reproduced.
+func Seed(tb testing.TB) uint64 {
+	tb.Helper()
+
+	value, origin := *seedFlag, "-random.seed flag"
+	if value == "" {
+		value, origin = os.Getenv(SeedEnv), SeedEnv+" environment variable"
+	}
+
+	if value == "" {
+		tb.Logf("random seed %d (default), %s", random.DefaultSeed, *sourceFlag)
+
+		return random.DefaultSeed
+	}
+
+	seed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		tb.Fatalf("could not parse random seed from %s: %v", origin, err)
+	}
+
+	tb.Logf("random seed %d (%s), %s", seed, origin, *sourceFlag)
+
+	return seed
+}
+
+// New returns a generator for the seed and source selected for the test run.
+func New(tb testing.TB) *random.Generator {
+	tb.Helper()
+
+	g, err := random.New(random.Source(*sourceFlag), Seed(tb))
+	if err != nil {
+		tb.Fatalf("could not create random generator: %v", err)
+	}
+
+	return g
+}
+
+// Lengths returns the string length distribution selected with -random.lengths flag, limited to [1, maxLength].
+func Lengths(tb testing.TB, maxLength int) random.LengthDist {
+	tb.Helper()
+
+	var dist random.LengthDist
+
+	switch *lengthFlag {
+	case "uniform":
+		return random.Uniform{Min: 1, Max: maxLength}
+	case "kubernetes":
+		dist = random.KubernetesNameLengths
+	default:
+		empirical, err := random.LoadEmpiricalCSV(*lengthFlag)
+		if err != nil {
+			tb.Fatalf("could not load string length distribution: %v", err)
+		}
+
+		dist = empirical
+	}
+
+	tb.Logf("string lengths %s, at most %d", *lengthFlag, maxLength)
+
+	return random.Truncated{Dist: dist, Min: 1, Max: maxLength}
+}
+
+// UnicodeSeeds returns fuzz seeds of count strings of each kind: mixed scripts, Latin with combining marks
+// and invalid UTF-8, with 1 to maxLength base runes.
+func UnicodeSeeds(g *random.Generator, count, maxLength int) []string {
+	seeds := make([]string, 0, 3*count)
+
+	for i := 0; i < count; i++ {
+		seeds = append(seeds,
+			g.UnicodeString(g.IntN(maxLength)+1, random.MixedScripts()),
+			g.UnicodeString(g.IntN(maxLength)+1, random.LatinMix().WithCombiningMarks(0.3)),
+			g.InvalidUTF8String(g.IntN(maxLength)+1, random.MixedScripts(), 0.1),
+		)
+	}
+
+	return seeds
+}
@@ -1,26 +1,103 @@
 package random
 
 import (
-	"math/rand"
+	"strings"
+	"unicode/utf8"
 )
 
 var (
-	// follow https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#dns-subdomain-names with '/' as namespace/name separator
+	// follow https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#dns-subdomain-names with '/' as namespace/name separator,
+	// it is only a character pool, use DNS1123Label, DNS1123Subdomain or NamespacedName for valid names
 	KubernetesNamesAllowedChars = []rune("abcdefghijklmnopqrstuvwxyz0123456789-./")
+	KubernetesNamesAllowedBytes = []byte(string(KubernetesNamesAllowedChars))
 )
 
-func Runes(size int, chars []rune) []rune {
+func (g *Generator) Runes(size int, chars []rune) []rune {
 	runes := make([]rune, size)
 
+	g.FillRunes(runes, chars)
+
+	return runes
+}
+
+// FillRunes fills dst with random chars without allocating.
+func (g *Generator) FillRunes(dst []rune, chars []rune) {
 	totalChars := len(chars)
 
-	for i := range runes {
-		runes[i] = chars[rand.Intn(totalChars)]
+	for i := range dst {
+		dst[i] = chars[g.IntN(totalChars)]
 	}
+}
 
-	return runes
+// FillBytes fills dst with random single-byte chars without allocating.
+func (g *Generator) FillBytes(dst []byte, chars []byte) {
+	totalChars := len(chars)
+
+	for i := range dst {
+		dst[i] = chars[g.IntN(totalChars)]
+	}
+}
+
+// AppendString appends UTF-8 encoding of size random chars to dst, it allocates only when dst has no capacity left.
+func (g *Generator) AppendString(dst []byte, size int, chars []rune) []byte {
+	totalChars := len(chars)
+
+	for i := 0; i < size; i++ {
+		dst = utf8.AppendRune(dst, chars[g.IntN(totalChars)])
+	}
+
+	return dst
+}
+
+func (g *Generator) String(size int, chars []rune) string {
+	var b strings.Builder
+
+	// Single-byte chars fill the builder exactly, longer ones grow it.
+	b.Grow(size)
+
+	totalChars := len(chars)
+
+	for i := 0; i < size; i++ {
+		b.WriteRune(chars[g.IntN(totalChars)])
+	}
+
+	return b.String()
 }
 
-func String(size int, chars []rune) string {
-	return string(Runes(size, chars))
+// Strings returns n strings of chars with lengths drawn from lengths. All strings are substrings of one string,
+// which is built once and stays alive while any of the strings is referenced.
+func (g *Generator) Strings(n int, lengths LengthDist, chars []rune) []string {
+	sizes := make([]int, n)
+
+	total := 0
+	for i := range sizes {
+		sizes[i] = lengths.Length(g)
+		total += sizes[i]
+	}
+
+	var b strings.Builder
+
+	b.Grow(total)
+
+	totalChars := len(chars)
+
+	// Sizes count runes, they are replaced by byte offsets of ends of the strings in the built one.
+	for i, size := range sizes {
+		for j := 0; j < size; j++ {
+			b.WriteRune(chars[g.IntN(totalChars)])
+		}
+
+		sizes[i] = b.Len()
+	}
+
+	all := b.String()
+	strs := make([]string, n)
+
+	start := 0
+	for i, end := range sizes {
+		strs[i] = all[start:end]
+		start = end
+	}
+
+	return strs
 }
@@ -0,0 +1,109 @@
+package random
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFillMatchesString(t *testing.T) {
+	chars := []rune("aß€😀")
+
+	expected := NewPCG(DefaultSeed).String(64, chars)
+
+	runes := make([]rune, 64)
+	NewPCG(DefaultSeed).FillRunes(runes, chars)
+
+	if string(runes) != expected {
+		t.Errorf("FillRunes mismatch: expected %q, got %q", expected, string(runes))
+	}
+
+	if got := NewPCG(DefaultSeed).AppendString([]byte("prefix/"), 64, chars); string(got) != "prefix/"+expected {
+		t.Errorf("AppendString mismatch: expected %q, got %q", "prefix/"+expected, got)
+	}
+
+	bytes := make([]byte, 64)
+	NewPCG(DefaultSeed).FillBytes(bytes, KubernetesNamesAllowedBytes)
+
+	if expected := NewPCG(DefaultSeed).String(64, KubernetesNamesAllowedChars); string(bytes) != expected {
+		t.Errorf("FillBytes mismatch: expected %q, got %q", expected, bytes)
+	}
+}
+
+func TestStrings(t *testing.T) {
+	chars := []rune("aß€😀")
+	lengths := Uniform{Min: 0, Max: 16}
+
+	strs := NewPCG(DefaultSeed).Strings(1000, lengths, chars)
+
+	g := NewPCG(DefaultSeed)
+
+	sizes := make([]int, len(strs))
+	for i := range sizes {
+		sizes[i] = lengths.Length(g)
+	}
+
+	for i, s := range strs {
+		if expected := g.String(sizes[i], chars); s != expected {
+			t.Fatalf("String %d mismatch: expected %q, got %q", i, expected, s)
+		}
+
+		if utf8.RuneCountInString(s) != sizes[i] {
+			t.Fatalf("String %d has %d runes, expected %d", i, utf8.RuneCountInString(s), sizes[i])
+		}
+	}
+}
+
+func TestAllocations(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	runes := make([]rune, 64)
+	bytes := make([]byte, 64)
+	buf := make([]byte, 0, 64)
+
+	for name, f := range map[string]func(){
+		"FillRunes":    func() { g.FillRunes(runes, KubernetesNamesAllowedChars) },
+		"FillBytes":    func() { g.FillBytes(bytes, KubernetesNamesAllowedBytes) },
+		"AppendString": func() { buf = g.AppendString(buf[:0], 64, KubernetesNamesAllowedChars) },
+	} {
+		if allocs := testing.AllocsPerRun(100, f); allocs != 0 {
+			t.Errorf("%s allocates %v times", name, allocs)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = g.String(64, KubernetesNamesAllowedChars) }); allocs != 1 {
+		t.Errorf("String allocates %v times, expected 1", allocs)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = g.Strings(1000, Uniform{Min: 1, Max: 64}, KubernetesNamesAllowedChars) }); allocs != 3 {
+		t.Errorf("Strings allocates %v times, expected 3", allocs)
+	}
+}
+
+func BenchmarkStrings(b *testing.B) {
+	const n = 1 << 16
+
+	var lengths LengthDist = Uniform{Min: 1, Max: 64}
+
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+
+		g := NewPCG(DefaultSeed)
+		strs := make([]string, n)
+
+		for i := 0; i < b.N; i++ {
+			for j := range strs {
+				strs[j] = g.StringOf(lengths, KubernetesNamesAllowedChars)
+			}
+		}
+	})
+
+	b.Run("Strings", func(b *testing.B) {
+		b.ReportAllocs()
+
+		g := NewPCG(DefaultSeed)
+
+		for i := 0; i < b.N; i++ {
+			_ = g.Strings(n, lengths, KubernetesNamesAllowedChars)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package trace
+
+import (
+	"code.local/go-benchmarks/random"
+)
+
+// Mix is the proportion of operations, proportions are expected to sum up to 1.
+type Mix struct {
+	Insert, Contains, Remove float64
+}
+
+type Config struct {
+	Operations int
+	Mix        Mix
+
+	// Keys is the universe of keys operations are drawn from.
+	Keys []string
+
+	// Locality is the probability that an operation reuses one of the last Window keys,
+	// e.g. a Pod that is updated and read repeatedly right after it was created.
+	Locality float64
+	Window   int
+}
+
+// Generate returns a trace of operations drawn from the generator, starting with an empty set.
+// Expected results are computed with a model of the set, so the trace can be replayed to any Set.
+func Generate(g *random.Generator, c Config) []Entry {
+	entries := make([]Entry, 0, c.Operations)
+
+	present := make(map[string]struct{})
+	recent := make([]string, 0, c.Window)
+
+	for len(entries) < c.Operations {
+		var key string
+
+		if len(recent) > 0 && g.Float64() < c.Locality {
+			key = recent[g.IntN(len(recent))]
+		} else {
+			key = c.Keys[g.IntN(len(c.Keys))]
+		}
+
+		e := Entry{Op: c.Mix.next(g), Key: key}
+
+		_, e.Expected = present[key]
+
+		switch e.Op {
+		case Insert:
+			e.Expected = !e.Expected
+			present[key] = struct{}{}
+		case Remove:
+			delete(present, key)
+		}
+
+		entries = append(entries, e)
+
+		// Recent keys are a ring buffer, oldest one is overwritten.
+		if len(recent) < c.Window {
+			recent = append(recent, key)
+		} else if c.Window > 0 {
+			recent[(len(entries)-1)%c.Window] = key
+		}
+	}
+
+	return entries
+}
+
+func (m Mix) next(g *random.Generator) Op {
+	p := g.Float64()
+
+	switch {
+	case p < m.Insert:
+		return Insert
+	case p < m.Insert+m.Contains:
+		return Contains
+	default:
+		return Remove
+	}
+}
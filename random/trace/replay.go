@@ -0,0 +1,75 @@
+package trace
+
+// Set is the interface that traces are recorded from and replayed to.
+type Set interface {
+	Insert(key string)
+	Contains(key string) bool
+	Remove(key string)
+}
+
+// Recorder is a Set that records every operation with its actual result before passing it to the wrapped set.
+// Results of Insert and Remove are observed with an extra Contains call.
+type Recorder struct {
+	set Set
+	w   *Writer
+	err error
+}
+
+func NewRecorder(s Set, w *Writer) *Recorder {
+	return &Recorder{set: s, w: w}
+}
+
+func (r *Recorder) record(op Op, key string, result bool) {
+	if r.err == nil {
+		r.err = r.w.Write(Entry{Op: op, Key: key, Expected: result})
+	}
+}
+
+func (r *Recorder) Insert(key string) {
+	present := r.set.Contains(key)
+
+	r.set.Insert(key)
+	r.record(Insert, key, !present)
+}
+
+func (r *Recorder) Contains(key string) bool {
+	found := r.set.Contains(key)
+
+	r.record(Contains, key, found)
+
+	return found
+}
+
+func (r *Recorder) Remove(key string) {
+	present := r.set.Contains(key)
+
+	r.set.Remove(key)
+	r.record(Remove, key, present)
+}
+
+// Err returns the first error of writing the trace, entries after it are not recorded.
+func (r *Recorder) Err() error {
+	return r.err
+}
+
+// Replay applies entries to the set and returns the number of Contains results that differ from expected ones,
+// e.g. false positives of probabilistic structures. Sets do not report results of Insert and Remove,
+// so only Contains entries are verified.
+func Replay(s Set, entries []Entry) int {
+	mismatches := 0
+
+	for _, e := range entries {
+		switch e.Op {
+		case Insert:
+			s.Insert(e.Key)
+		case Contains:
+			if s.Contains(e.Key) != e.Expected {
+				mismatches++
+			}
+		case Remove:
+			s.Remove(e.Key)
+		}
+	}
+
+	return mismatches
+}
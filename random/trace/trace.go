@@ -0,0 +1,204 @@
+package trace
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/*
+	Trace is a text file with one operation per line
+		<op> <quoted key> <expected result>
+	e.g.
+		insert "ns-1/api-5d8f9c7b4x-k2xqz" true
+		contains "ns-1/api-5d8f9c7b4x-k2xqz" true
+		remove "ns-1/api-5d8f9c7b4x-k2xqz" true
+	where expected result is true if insert added a new key, contains found the key and remove deleted an existing one.
+	Keys are Go-quoted, so they may hold any bytes. Empty lines and lines starting with '#' are ignored.
+*/
+
+type Op uint8
+
+const (
+	Insert Op = iota
+	Contains
+	Remove
+)
+
+func (op Op) String() string {
+	switch op {
+	case Insert:
+		return "insert"
+	case Contains:
+		return "contains"
+	case Remove:
+		return "remove"
+	default:
+		return fmt.Sprintf("Op(%d)", op)
+	}
+}
+
+func ParseOp(s string) (Op, error) {
+	switch s {
+	case "insert":
+		return Insert, nil
+	case "contains":
+		return Contains, nil
+	case "remove":
+		return Remove, nil
+	default:
+		return 0, fmt.Errorf("unknown op %q", s)
+	}
+}
+
+type Entry struct {
+	Op       Op
+	Key      string
+	Expected bool
+}
+
+func (e Entry) String() string {
+	return e.Op.String() + " " + strconv.Quote(e.Key) + " " + strconv.FormatBool(e.Expected)
+}
+
+// ParseEntry parses a single trace line.
+func ParseEntry(line string) (Entry, error) {
+	name, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		return Entry{}, errors.New("expected op, key and expected result")
+	}
+
+	op, err := ParseOp(name)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	quoted, err := strconv.QuotedPrefix(rest)
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid key: %w", err)
+	}
+
+	key, _ := strconv.Unquote(quoted)
+
+	expected, err := strconv.ParseBool(strings.TrimPrefix(rest[len(quoted):], " "))
+	if err != nil {
+		return Entry{}, fmt.Errorf("invalid expected result: %w", err)
+	}
+
+	return Entry{Op: op, Key: key, Expected: expected}, nil
+}
+
+type Writer struct {
+	w *bufio.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+func (w *Writer) Write(e Entry) error {
+	_, err := w.w.WriteString(e.String() + "\n")
+
+	return err
+}
+
+// Flush writes buffered entries to the underlying writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+type Reader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{s: bufio.NewScanner(r)}
+}
+
+// Read returns the next entry, or io.EOF at the end of the trace.
+func (r *Reader) Read() (Entry, error) {
+	for r.s.Scan() {
+		r.line++
+
+		line := strings.TrimSpace(r.s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		e, err := ParseEntry(line)
+		if err != nil {
+			return Entry{}, fmt.Errorf("line %d: %w", r.line, err)
+		}
+
+		return e, nil
+	}
+
+	if err := r.s.Err(); err != nil {
+		return Entry{}, err
+	}
+
+	return Entry{}, io.EOF
+}
+
+func ReadAll(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	reader := NewReader(r)
+
+	for {
+		e, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, e)
+	}
+}
+
+// Load reads all entries of a trace file.
+func Load(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entries, err := ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// Save writes entries to a trace file.
+func Save(path string, entries []Entry) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := NewWriter(f)
+
+	for _, e := range entries {
+		if err := w.Write(e); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
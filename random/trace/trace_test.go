@@ -0,0 +1,189 @@
+package trace
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"code.local/go-benchmarks/random"
+)
+
+type mapSet map[string]struct{}
+
+func (s mapSet) Insert(key string)        { s[key] = struct{}{} }
+func (s mapSet) Contains(key string) bool { _, ok := s[key]; return ok }
+func (s mapSet) Remove(key string)        { delete(s, key) }
+
+func TestParseEntry(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected Entry
+		wantErr  bool
+	}{
+		{`insert "ns-1/a" true`, Entry{Insert, "ns-1/a", true}, false},
+		{`contains "with space \"quoted\"" false`, Entry{Contains, `with space "quoted"`, false}, false},
+		{`remove "\xffé" true`, Entry{Remove, "\xffé", true}, false},
+		{`update "a" true`, Entry{}, true},
+		{`insert a true`, Entry{}, true},
+		{`insert "a" maybe`, Entry{}, true},
+		{`insert`, Entry{}, true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseEntry(tc.line)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseEntry(%q) returned error %v", tc.line, err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("ParseEntry(%q) mismatch: expected %v, got %v", tc.line, tc.expected, got)
+		}
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	entries := []Entry{
+		{Insert, "ns-1/a", true},
+		{Contains, "ns-1/a b", true},
+		{Remove, "\xff", false},
+	}
+
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf)
+	for _, e := range entries {
+		if err := w.Write(e); err != nil {
+			t.Fatalf("Write returned an error: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush returned an error: %v", err)
+	}
+
+	got, err := ReadAll(strings.NewReader("# comment\n\n" + buf.String()))
+	if err != nil {
+		t.Fatalf("ReadAll returned an error: %v", err)
+	}
+
+	if !slices.Equal(got, entries) {
+		t.Errorf("Entries mismatch: expected %v, got %v", entries, got)
+	}
+
+	if _, err := ReadAll(strings.NewReader("insert \"a\" true\ninsert\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error at line 2, got %v", err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g := random.NewPCG(random.DefaultSeed)
+
+	keys, err := g.UniqueStrings(100, random.KubernetesNameLengths, random.KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("UniqueStrings returned an error: %v", err)
+	}
+
+	c := Config{
+		Operations: 10000,
+		Mix:        Mix{Insert: 0.3, Contains: 0.5, Remove: 0.2},
+		Keys:       keys,
+		Locality:   0.8,
+		Window:     4,
+	}
+
+	entries := Generate(g, c)
+	if len(entries) != c.Operations {
+		t.Fatalf("Expected %d entries, got %d", c.Operations, len(entries))
+	}
+
+	if mismatches := Replay(mapSet{}, entries); mismatches != 0 {
+		t.Fatalf("Replay to a map has %d mismatches", mismatches)
+	}
+
+	counts := map[Op]int{}
+	repeated := 0
+
+	for i, e := range entries {
+		counts[e.Op]++
+
+		if i > 0 && slices.ContainsFunc(entries[max(0, i-4):i], func(p Entry) bool { return p.Key == e.Key }) {
+			repeated++
+		}
+	}
+
+	if counts[Contains] < 4500 || counts[Contains] > 5500 || counts[Remove] < 1500 || counts[Remove] > 2500 {
+		t.Errorf("Unexpected op mix %v", counts)
+	}
+
+	if repeated < 7500 {
+		t.Errorf("Expected temporal locality, only %d ops reuse one of last 4 keys", repeated)
+	}
+
+	if !slices.Equal(Generate(random.NewPCG(42), c), Generate(random.NewPCG(42), c)) {
+		t.Error("Same seed produced different traces")
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.txt")
+
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf)
+	r := NewRecorder(mapSet{}, w)
+
+	r.Insert("a")
+	r.Insert("a")
+	r.Contains("b")
+	r.Remove("a")
+	r.Remove("a")
+	r.Contains("a")
+
+	if err := w.Flush(); err != nil || r.Err() != nil {
+		t.Fatalf("Recording failed: %v, %v", err, r.Err())
+	}
+
+	entries, err := ReadAll(&buf)
+	if err != nil {
+		t.Fatalf("ReadAll returned an error: %v", err)
+	}
+
+	expected := []Entry{
+		{Insert, "a", true},
+		{Insert, "a", false},
+		{Contains, "b", false},
+		{Remove, "a", true},
+		{Remove, "a", false},
+		{Contains, "a", false},
+	}
+
+	if !slices.Equal(entries, expected) {
+		t.Fatalf("Recorded entries mismatch: expected %v, got %v", expected, entries)
+	}
+
+	if err := Save(path, entries); err != nil {
+		t.Fatalf("Save returned an error: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned an error: %v", err)
+	}
+
+	if mismatches := Replay(mapSet{}, loaded); mismatches != 0 {
+		t.Errorf("Replay has %d mismatches", mismatches)
+	}
+
+	// Set that contains everything mismatches on both absent lookups.
+	if mismatches := Replay(alwaysSet{}, loaded); mismatches != 2 {
+		t.Errorf("Expected 2 mismatches, got %d", mismatches)
+	}
+}
+
+type alwaysSet struct{}
+
+func (alwaysSet) Insert(string)        {}
+func (alwaysSet) Contains(string) bool { return true }
+func (alwaysSet) Remove(string)        {}
@@ -0,0 +1,168 @@
+package random
+
+import (
+	"sort"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ScriptWeight is a relative weight of a script, or any other range table, in a ScriptMix.
+type ScriptWeight struct {
+	Table  *unicode.RangeTable
+	Weight float64
+}
+
+// ScriptMix draws runes from weighted scripts, optionally followed by combining marks.
+type ScriptMix struct {
+	scripts    [][]rune
+	cumulative []float64
+
+	combiningMarks float64
+}
+
+// NewScriptMix returns a mix drawing every rune from a script chosen by its weight.
+func NewScriptMix(weights ...ScriptWeight) *ScriptMix {
+	mix := &ScriptMix{}
+
+	total := 0.0
+
+	for _, w := range weights {
+		total += w.Weight
+
+		mix.scripts = append(mix.scripts, tableRunes(w.Table))
+		mix.cumulative = append(mix.cumulative, total)
+	}
+
+	return mix
+}
+
+// WithCombiningMarks returns a copy of the mix where every base rune is followed by a combining mark
+// (unicode.Mn) with probability p, e.g. "é" instead of "é".
+func (mix *ScriptMix) WithCombiningMarks(p float64) *ScriptMix {
+	c := *mix
+	c.combiningMarks = p
+
+	return &c
+}
+
+func tableRunes(table *unicode.RangeTable) []rune {
+	var runes []rune
+
+	for _, r := range table.R16 {
+		for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+			runes = append(runes, c)
+		}
+	}
+
+	for _, r := range table.R32 {
+		for c := rune(r.Lo); c <= rune(r.Hi); c += rune(r.Stride) {
+			runes = append(runes, c)
+		}
+	}
+
+	return runes
+}
+
+var combiningMarks = sync.OnceValue(func() []rune {
+	return tableRunes(unicode.Mn)
+})
+
+// LatinMix draws cased Latin letters, including accented ones and ones whose lowercase has a different UTF-8 length,
+// 'İ' and Kelvin sign 'K' lowercase to ASCII 'i' and 'k'.
+var LatinMix = sync.OnceValue(func() *ScriptMix {
+	return NewScriptMix(
+		ScriptWeight{unicode.Latin, 8},
+		ScriptWeight{&unicode.RangeTable{R16: []unicode.Range16{{Lo: 0x130, Hi: 0x130, Stride: 1}, {Lo: 0x212a, Hi: 0x212a, Stride: 1}}}, 1},
+	)
+})
+
+// MixedScripts draws runes from Latin, Greek, Cyrillic, Arabic, Devanagari, Hangul and Han scripts and symbols
+// including emoji, so strings have 1 to 4 bytes per rune.
+var MixedScripts = sync.OnceValue(func() *ScriptMix {
+	return NewScriptMix(
+		ScriptWeight{unicode.Latin, 4},
+		ScriptWeight{unicode.Greek, 1},
+		ScriptWeight{unicode.Cyrillic, 2},
+		ScriptWeight{unicode.Arabic, 1},
+		ScriptWeight{unicode.Devanagari, 1},
+		ScriptWeight{unicode.Hangul, 1},
+		ScriptWeight{unicode.Han, 2},
+		ScriptWeight{unicode.So, 1},
+	)
+})
+
+// UnicodeRunes returns size base runes drawn from the mix, followed by combining marks if the mix has them.
+func (g *Generator) UnicodeRunes(size int, mix *ScriptMix) []rune {
+	runes := make([]rune, 0, size)
+
+	for i := 0; i < size; i++ {
+		script := mix.scripts[sort.SearchFloat64s(mix.cumulative, g.Float64()*mix.cumulative[len(mix.cumulative)-1])]
+
+		runes = append(runes, script[g.IntN(len(script))])
+
+		if mix.combiningMarks > 0 && g.Float64() < mix.combiningMarks {
+			marks := combiningMarks()
+
+			runes = append(runes, marks[g.IntN(len(marks))])
+		}
+	}
+
+	return runes
+}
+
+// UnicodeString returns a valid UTF-8 string of size base runes drawn from the mix.
+func (g *Generator) UnicodeString(size int, mix *ScriptMix) string {
+	return string(g.UnicodeRunes(size, mix))
+}
+
+// invalidUTF8 are byte sequences rejected by utf8.Valid: unexpected continuation byte, truncated sequences,
+// overlong encodings, UTF-16 surrogate, code point above U+10FFFF and bytes never used in UTF-8.
+var invalidUTF8 = [][]byte{
+	{0x80},
+	{0xbf},
+	{0xc3},
+	{0xe2, 0x82},
+	{0xf0, 0x9f, 0x98},
+	{0xc0, 0xaf},
+	{0xe0, 0x80, 0xaf},
+	{0xed, 0xa0, 0x80},
+	{0xf4, 0x90, 0x80, 0x80},
+	{0xfe},
+	{0xff},
+}
+
+// InvalidUTF8String returns a string of size base runes drawn from the mix, where every rune is replaced by
+// an invalid UTF-8 sequence with probability p. At least one rune is replaced when p > 0 and size > 0.
+func (g *Generator) InvalidUTF8String(size int, mix *ScriptMix, p float64) string {
+	runes := g.UnicodeRunes(size, mix)
+
+	forced := -1
+	if p > 0 && size > 0 {
+		forced = g.IntN(len(runes))
+	}
+
+	buf := make([]byte, 0, len(runes)*utf8.UTFMax)
+	replaced := false
+
+	for i, r := range runes {
+		if i != forced && (p == 0 || g.Float64() >= p) {
+			buf = utf8.AppendRune(buf, r)
+			replaced = false
+
+			continue
+		}
+
+		seq := invalidUTF8[g.IntN(len(invalidUTF8))]
+
+		// Continuation byte could complete a truncated sequence before it, e.g. 0xc3 0x80 is valid "À".
+		for replaced && !utf8.RuneStart(seq[0]) {
+			seq = invalidUTF8[g.IntN(len(invalidUTF8))]
+		}
+
+		buf = append(buf, seq...)
+		replaced = true
+	}
+
+	return string(buf)
+}
@@ -0,0 +1,105 @@
+package random
+
+import (
+	"testing"
+	"unicode"
+	"unicode/utf8"
+)
+
+func TestUnicodeString(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	multiByte := 0
+
+	for i := 0; i < 1000; i++ {
+		s := g.UnicodeString(16, MixedScripts())
+
+		if !utf8.ValidString(s) {
+			t.Fatalf("Invalid UTF-8 %q", s)
+		}
+
+		if utf8.RuneCountInString(s) != 16 {
+			t.Fatalf("Expected 16 runes, got %d in %q", utf8.RuneCountInString(s), s)
+		}
+
+		if len(s) > 16 {
+			multiByte++
+		}
+	}
+
+	if multiByte == 0 {
+		t.Error("No multi-byte strings generated")
+	}
+
+	if NewPCG(DefaultSeed).UnicodeString(16, MixedScripts()) != NewPCG(DefaultSeed).UnicodeString(16, MixedScripts()) {
+		t.Error("Same seed produced different strings")
+	}
+}
+
+func TestScriptMix(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+	mix := NewScriptMix(ScriptWeight{unicode.Greek, 1}, ScriptWeight{unicode.Cyrillic, 3})
+
+	counts := map[string]int{}
+
+	for _, r := range g.UnicodeRunes(10000, mix) {
+		switch {
+		case unicode.Is(unicode.Greek, r):
+			counts["greek"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["cyrillic"]++
+		default:
+			t.Fatalf("Unexpected rune %q", r)
+		}
+	}
+
+	if ratio := float64(counts["cyrillic"]) / float64(counts["greek"]); ratio < 2.7 || ratio > 3.3 {
+		t.Errorf("Expected about 3 Cyrillic runes per Greek one, got %v", ratio)
+	}
+}
+
+func TestCombiningMarks(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	runes := g.UnicodeRunes(10000, LatinMix().WithCombiningMarks(0.5))
+
+	marks := 0
+
+	for i, r := range runes {
+		if unicode.Is(unicode.Mn, r) {
+			marks++
+
+			if i == 0 || unicode.Is(unicode.Mn, runes[i-1]) {
+				t.Fatalf("Combining mark at %d does not follow a base rune", i)
+			}
+		}
+	}
+
+	if bases := len(runes) - marks; bases != 10000 || marks < 4500 || marks > 5500 {
+		t.Errorf("Expected 10000 base runes with about 5000 marks, got %d and %d", bases, marks)
+	}
+
+	if LatinMix().combiningMarks != 0 {
+		t.Error("WithCombiningMarks modified the original mix")
+	}
+}
+
+func TestInvalidUTF8String(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+
+	for i := 0; i < 1000; i++ {
+		if s := g.InvalidUTF8String(g.IntN(16)+1, MixedScripts(), 0.1); utf8.ValidString(s) {
+			t.Fatalf("Valid UTF-8 %q", s)
+		}
+	}
+
+	for _, seq := range invalidUTF8 {
+		if utf8.Valid(seq) {
+			t.Errorf("Sequence %x is valid UTF-8", seq)
+		}
+	}
+
+	if s := g.InvalidUTF8String(16, MixedScripts(), 0); !utf8.ValidString(s) {
+		t.Errorf("Invalid UTF-8 %q without replacements", s)
+	}
+}
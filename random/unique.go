@@ -0,0 +1,55 @@
+package random
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxDuplicates bounds consecutive duplicates drawn before the key space is considered exhausted.
+const maxDuplicates = 1 << 10
+
+var ErrKeySpaceExhausted = errors.New("key space exhausted")
+
+// UniqueStrings returns n distinct strings of chars with lengths drawn from lengths. Output is deterministic
+// for the generator state, duplicates are redrawn. It fails when lengths and chars can not produce n distinct strings.
+func (g *Generator) UniqueStrings(n int, lengths LengthDist, chars []rune) ([]string, error) {
+	return g.unique(n, make(map[string]struct{}, n), lengths, chars)
+}
+
+// Disjoint returns n distinct strings, as UniqueStrings does, none of which is in exclude,
+// e.g. keys for negative lookups into a set loaded with exclude.
+func (g *Generator) Disjoint(n int, exclude []string, lengths LengthDist, chars []rune) ([]string, error) {
+	seen := make(map[string]struct{}, n+len(exclude))
+	for _, key := range exclude {
+		seen[key] = struct{}{}
+	}
+
+	return g.unique(n, seen, lengths, chars)
+}
+
+func (g *Generator) unique(n int, seen map[string]struct{}, lengths LengthDist, chars []rune) ([]string, error) {
+	keys := make([]string, 0, n)
+
+	// Candidates are generated into a reused buffer, only unique ones are copied into strings.
+	var buf []byte
+
+	for duplicates := 0; len(keys) < n; {
+		buf = g.AppendString(buf[:0], lengths.Length(g), chars)
+
+		if _, ok := seen[string(buf)]; ok {
+			if duplicates++; duplicates > maxDuplicates {
+				return nil, fmt.Errorf("%w: %d consecutive duplicates after %d of %d strings", ErrKeySpaceExhausted, duplicates, len(keys), n)
+			}
+
+			continue
+		}
+
+		key := string(buf)
+
+		duplicates = 0
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,86 @@
+package random
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestUniqueStrings(t *testing.T) {
+	// Lengths 1-2 of 39 characters allow only 1560 distinct strings, so plain generation collides often.
+	lengths := Uniform{Min: 1, Max: 2}
+
+	keys, err := NewPCG(DefaultSeed).UniqueStrings(1500, lengths, KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("UniqueStrings returned an error: %v", err)
+	}
+
+	if len(keys) != 1500 {
+		t.Fatalf("Expected 1500 strings, got %d", len(keys))
+	}
+
+	if len(distinct(keys)) != len(keys) {
+		t.Fatal("UniqueStrings returned duplicates")
+	}
+
+	again, _ := NewPCG(DefaultSeed).UniqueStrings(1500, lengths, KubernetesNamesAllowedChars)
+	if !slices.Equal(keys, again) {
+		t.Fatal("Same seed produced different strings")
+	}
+
+	if _, err := NewPCG(DefaultSeed).UniqueStrings(1561, lengths, KubernetesNamesAllowedChars); !errors.Is(err, ErrKeySpaceExhausted) {
+		t.Fatalf("Expected ErrKeySpaceExhausted, got %v", err)
+	}
+}
+
+func TestDisjoint(t *testing.T) {
+	g := NewPCG(DefaultSeed)
+	lengths := Uniform{Min: 1, Max: 2}
+
+	exclude, err := g.UniqueStrings(1000, lengths, KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("UniqueStrings returned an error: %v", err)
+	}
+
+	keys, err := g.Disjoint(500, exclude, lengths, KubernetesNamesAllowedChars)
+	if err != nil {
+		t.Fatalf("Disjoint returned an error: %v", err)
+	}
+
+	excluded := distinct(exclude)
+
+	for _, key := range keys {
+		if _, ok := excluded[key]; ok {
+			t.Fatalf("Disjoint returned excluded %q", key)
+		}
+	}
+
+	if len(distinct(keys)) != len(keys) {
+		t.Fatal("Disjoint returned duplicates")
+	}
+
+	if _, err := g.Disjoint(561, exclude, lengths, KubernetesNamesAllowedChars); !errors.Is(err, ErrKeySpaceExhausted) {
+		t.Fatalf("Expected ErrKeySpaceExhausted, got %v", err)
+	}
+}
+
+func distinct(keys []string) map[string]struct{} {
+	m := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		m[key] = struct{}{}
+	}
+
+	return m
+}
+
+func BenchmarkUniqueStrings(b *testing.B) {
+	const n = 1 << 20
+
+	for i := 0; i < b.N; i++ {
+		if _, err := NewPCG(DefaultSeed).UniqueStrings(n, KubernetesNameLengths, KubernetesNamesAllowedChars); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*n), "ns/key")
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"math"
-	"math/rand"
 	"strings"
 	"testing"
 
@@ -22,14 +21,17 @@ import (
 	"code.local/go-benchmarks/charhashmatrix"
 	"code.local/go-benchmarks/charmatrix3d"
 	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
 )
 
 func BenchmarkSets(b *testing.B) {
 	size := math.MaxUint8
+	g := randomtest.New(b)
+	lengths := randomtest.Lengths(b, size)
 
-	tt := make([]string, size)
-	for i := range tt {
-		tt[i] = random.String(rand.Intn(size)+1, random.KubernetesNamesAllowedChars)
+	tt, err := g.UniqueStrings(size, lengths, random.KubernetesNamesAllowedChars)
+	if err != nil {
+		b.Fatal(err)
 	}
 
 	{
@@ -461,3 +463,54 @@ func BenchmarkSets(b *testing.B) {
 		})
 	}
 }
+
+// benchmarkSetKeys times insert, contains and remove of all keys in s. Before the timer, it counts keys the set loses,
+// e.g. full cuckoo buckets or characters the char matrix does not support, like '_' of paths like node_modules,
+// and reports their proportion as lost-keys. If absent keys are given, the proportion of them the set contains,
+// e.g. saturated hash matrix rows, is reported as false-positives. It returns the number of lost keys.
+func benchmarkSetKeys(b *testing.B, s set, keys, absent []string) int {
+	lost, falsePositives := 0, 0
+
+	for _, key := range keys {
+		s.Insert(key)
+	}
+
+	for _, key := range keys {
+		if !s.Contains(key) {
+			lost++
+		}
+	}
+
+	for _, key := range absent {
+		if s.Contains(key) {
+			falsePositives++
+		}
+	}
+
+	for _, key := range keys {
+		s.Remove(key)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			s.Insert(key)
+		}
+
+		for _, key := range keys {
+			_ = s.Contains(key)
+		}
+
+		for _, key := range keys {
+			s.Remove(key)
+		}
+	}
+
+	b.ReportMetric(float64(lost)/float64(len(keys)), "lost-keys")
+
+	if len(absent) > 0 {
+		b.ReportMetric(float64(falsePositives)/float64(len(absent)), "false-positives")
+	}
+
+	return lost
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"testing"
+
+	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
+	"code.local/go-benchmarks/random/trace"
+)
+
+// go test -bench=BenchmarkSetsTrace -replay=informer.trace .
+
+var (
+	replayFlag       = flag.String("replay", "", "trace file replayed by BenchmarkSetsTrace instead of a generated one")
+	replayRecordFlag = flag.String("replay.record", "", "file to save the generated trace of BenchmarkSetsTrace to")
+)
+
+func BenchmarkSetsTrace(b *testing.B) {
+	entries := benchmarkTrace(b)
+
+	keys := make(map[string]struct{})
+	for _, e := range entries {
+		keys[e.Key] = struct{}{}
+	}
+
+	for _, f := range setFactories {
+		b.Run(f.name, func(b *testing.B) {
+			mismatches := 0
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				s := f.new(len(keys))
+				b.StartTimer()
+
+				mismatches += trace.Replay(s, entries)
+			}
+
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(entries)), "ns/entry")
+			b.ReportMetric(float64(mismatches)/float64(b.N*len(entries)), "mismatches/entry")
+		})
+	}
+}
+
+// benchmarkTrace loads the trace given by -replay flag, or generates Pod churn with temporal locality.
+func benchmarkTrace(b *testing.B) []trace.Entry {
+	b.Helper()
+
+	if *replayFlag != "" {
+		entries, err := trace.Load(*replayFlag)
+		if err != nil {
+			b.Fatalf("could not load trace: %v", err)
+		}
+
+		b.Logf("replaying %d entries from %s", len(entries), *replayFlag)
+
+		return entries
+	}
+
+	g := randomtest.New(b)
+
+	keys, err := random.KubernetesPods(4, 8, 2, 8).Generate(g)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	entries := trace.Generate(g, trace.Config{
+		Operations: 1 << 14,
+		Mix:        trace.Mix{Insert: 0.3, Contains: 0.6, Remove: 0.1},
+		Keys:       keys,
+		Locality:   0.5,
+		Window:     16,
+	})
+
+	if *replayRecordFlag != "" {
+		if err := trace.Save(*replayRecordFlag, entries); err != nil {
+			b.Fatalf("could not save trace: %v", err)
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+func FuzzSets(f *testing.F) {
+	g := randomtest.New(f)
+
+	for _, seed := range randomtest.UnicodeSeeds(g, 16, 32) {
+		f.Add(seed)
+	}
+
+	// Keys that structures can not store, they are only checked not to panic.
+	unsupported := map[string]func(key string) bool{
+		"local/char-matrix-3d": charMatrixRejects,
+		// gorax splits nodes with string(key[i]), which turns bytes above 0x7f into two-byte runes.
+		"snorwin/gorax": func(key string) bool {
+			for i := 0; i < len(key); i++ {
+				if key[i] >= utf8.RuneSelf {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+
+	f.Fuzz(func(t *testing.T, key string) {
+		if key == "" {
+			t.Skip("empty keys are not supported by all sets")
+		}
+
+		for _, factory := range setFactories {
+			s := factory.new(1)
+
+			s.Insert(key)
+
+			if isUnsupported, ok := unsupported[factory.name]; ok && isUnsupported(key) {
+				s.Remove(key)
+
+				continue
+			}
+
+			if !s.Contains(key) {
+				t.Fatalf("%s: Does not contain %q after inserting", factory.name, key)
+			}
+
+			s.Remove(key)
+
+			if s.Contains(key) {
+				t.Fatalf("%s: Contains %q after removing", factory.name, key)
+			}
+		}
+	})
+}
+
+// charMatrixRejects reports whether the character matrix rejects the key, it stores keys of at most 255
+// Kubernetes name characters in any case.
+func charMatrixRejects(key string) bool {
+	runes := []rune(key)
+	if len(runes) > math.MaxUint8 {
+		return true
+	}
+
+	for _, r := range runes {
+		if !slices.Contains(random.KubernetesNamesAllowedChars, unicode.ToLower(r)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.local/go-benchmarks/histogram"
+	"code.local/go-benchmarks/random"
+	"code.local/go-benchmarks/random/randomtest"
+)
+
+/*
+	YCSB core workloads, see https://github.com/brianfrankcooper/YCSB/wiki/Core-Workloads
+		A - update heavy, 50% reads and 50% updates
+		B - read mostly, 95% reads and 5% updates
+		C - read only
+		D - read latest, 95% reads and 5% inserts, recently inserted keys are the most popular
+		E - short ranges, 95% scans and 5% inserts
+		F - read-modify-write, 50% reads and 50% read-modify-writes
+*/
+
+type ycsbOpKind uint8
+
+const (
+	ycsbRead ycsbOpKind = iota
+	ycsbUpdate
+	ycsbInsert
+	ycsbScan
+	ycsbReadModifyWrite
+)
+
+type ycsbDistribution uint8
+
+const (
+	ycsbUniform ycsbDistribution = iota
+	ycsbZipfian
+	ycsbLatest
+)
+
+type ycsbWorkload struct {
+	name string
+
+	// Proportions of operations, they are expected to sum up to 1.
+	read, update, insert, scan, readModifyWrite float64
+
+	distribution  ycsbDistribution
+	maxScanLength int
+}
+
+var ycsbWorkloads = []ycsbWorkload{
+	{name: "A/update-heavy", read: 0.5, update: 0.5, distribution: ycsbZipfian},
+	{name: "B/read-mostly", read: 0.95, update: 0.05, distribution: ycsbZipfian},
+	{name: "C/read-only", read: 1, distribution: ycsbZipfian},
+	{name: "D/read-latest", read: 0.95, insert: 0.05, distribution: ycsbLatest},
+	{name: "E/scan-short-ranges", scan: 0.95, insert: 0.05, distribution: ycsbZipfian, maxScanLength: 100},
+	{name: "F/read-modify-write", read: 0.5, readModifyWrite: 0.5, distribution: ycsbZipfian},
+}
+
+type ycsbOp struct {
+	kind       ycsbOpKind
+	key        int // index of a key, see ycsbKey
+	scanLength int
+}
+
+// ycsbKey returns the key for a record index, hashing spreads sequential inserts over the key space.
+func ycsbKey(i int) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strconv.Itoa(i)))
+
+	return "user" + strconv.FormatUint(h.Sum64(), 10)
+}
+
+func (w ycsbWorkload) nextKind(g *random.Generator) ycsbOpKind {
+	p := g.Float64()
+
+	for _, c := range []struct {
+		kind       ycsbOpKind
+		proportion float64
+	}{
+		{ycsbRead, w.read},
+		{ycsbUpdate, w.update},
+		{ycsbInsert, w.insert},
+		{ycsbScan, w.scan},
+		{ycsbReadModifyWrite, w.readModifyWrite},
+	} {
+		if p < c.proportion {
+			return c.kind
+		}
+
+		p -= c.proportion
+	}
+
+	return ycsbRead
+}
+
+// generate deterministically produces an operation stream from the generator, where first recordCount
+// keys are expected to be loaded before the stream is applied. It returns total number of keys referenced.
+func (w ycsbWorkload) generate(g *random.Generator, recordCount, operationCount int) ([]ycsbOp, int) {
+	zipf := rand.NewZipf(g.Rand, 1.1, 1, uint64(recordCount+operationCount))
+
+	ops := make([]ycsbOp, operationCount)
+	keyCount := recordCount
+
+	for i := range ops {
+		op := ycsbOp{kind: w.nextKind(g)}
+
+		switch {
+		case op.kind == ycsbInsert:
+			op.key = keyCount
+			keyCount++
+		case w.distribution == ycsbZipfian:
+			op.key = int(zipf.Uint64() % uint64(keyCount))
+		case w.distribution == ycsbLatest:
+			op.key = keyCount - 1 - int(zipf.Uint64()%uint64(keyCount))
+		default:
+			op.key = g.IntN(keyCount)
+		}
+
+		if op.kind == ycsbScan {
+			op.scanLength = g.IntN(w.maxScanLength) + 1
+		}
+
+		ops[i] = op
+	}
+
+	return ops, keyCount
+}
+
+func BenchmarkSetsYCSB(b *testing.B) {
+	const (
+		recordCount    = 1000
+		operationCount = 10000
+	)
+
+	g := randomtest.New(b)
+
+	for i, w := range ycsbWorkloads {
+		ops, keyCount := w.generate(g.Stream(uint64(i)), recordCount, operationCount)
+
+		keys := make([]string, keyCount)
+		for i := range keys {
+			keys[i] = ycsbKey(i)
+		}
+
+		b.Run(w.name, func(b *testing.B) {
+			for _, f := range setFactories {
+				b.Run(f.name, func(b *testing.B) {
+					s := f.new(keyCount)
+
+					scanner, ok := s.(rangeScanner)
+					if w.scan > 0 && !ok {
+						b.Skip("unordered structure, scans are not supported")
+					}
+
+					for _, key := range keys[:recordCount] {
+						s.Insert(key)
+					}
+
+					var (
+						visited int
+						start   time.Time
+						latency = histogram.New()
+					)
+
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						op := ops[i%len(ops)]
+						key := keys[op.key]
+
+						if *latencyFlag {
+							start = time.Now()
+						}
+
+						switch op.kind {
+						case ycsbRead:
+							if !s.Contains(key) {
+								b.FailNow()
+							}
+						case ycsbUpdate, ycsbInsert:
+							s.Insert(key)
+						case ycsbScan:
+							scanner.Scan(key, op.scanLength, func(string) bool {
+								visited++
+
+								return true
+							})
+						case ycsbReadModifyWrite:
+							if !s.Contains(key) {
+								b.FailNow()
+							}
+
+							s.Insert(key)
+						}
+
+						if *latencyFlag {
+							latency.RecordDuration(time.Since(start))
+						}
+					}
+
+					if w.scan > 0 {
+						b.ReportMetric(float64(visited)/float64(b.N), "keys/op")
+					}
+
+					reportLatency(b, latency)
+				})
+			}
+		})
+	}
+}